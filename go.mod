@@ -2,9 +2,4 @@ module github.com/phoebetronic/orderbook-kraken
 
 go 1.20
 
-require github.com/sacOO7/gowebsocket v0.0.0-20221109081133-70ac927be105
-
-require (
-	github.com/gorilla/websocket v1.4.2 // indirect
-	github.com/sacOO7/go-logger v0.0.0-20180719173527-9ac9add5a50d // indirect
-)
+require github.com/gorilla/websocket v1.4.2
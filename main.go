@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
-	"strings"
+	"time"
 
-	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
-	"github.com/sacOO7/gowebsocket"
+	"github.com/phoebetronic/orderbook-kraken/pkg/capture"
+	"github.com/phoebetronic/orderbook-kraken/pkg/client"
 )
 
 func main() {
+	var dir string
+	{
+		flag.StringVar(&dir, "capture", "", "directory to record all raw websocket messages to")
+		flag.Parse()
+	}
+
 	fmt.Println()
 	fmt.Println("|===========================================|")
 	fmt.Println("|            wss://ws.kraken.com            |")
@@ -20,9 +28,9 @@ func main() {
 
 	{
 		api := "wss://ws.kraken.com"
-		sub := "{ \"event\":\"subscribe\", \"subscription\":{\"name\":\"book\"},\"pair\":[\"ETH/USD\"] }"
+		dep := 10
 
-		OpenAndStreamWebSocketSubscription(api, sub)
+		OpenAndStreamWebSocketSubscription(api, dep, dir)
 	}
 
 	fmt.Println()
@@ -32,94 +40,97 @@ func main() {
 	fmt.Println()
 }
 
-func OpenAndStreamWebSocketSubscription(api, sub string) {
-	var cli gowebsocket.Socket
+func OpenAndStreamWebSocketSubscription(api string, dep int, dir string) {
+	var con client.Config
 	{
-		cli = gowebsocket.New(api)
-	}
-
-	var obk *orderbook.Orderbook
-	{
-		obk = orderbook.New()
+		con = client.Config{
+			API: api,
+			Dep: dep,
+			Err: func(err error) {
+				fmt.Println("Received error - ", err)
+			},
+			Pai: []string{"ETH/USD"},
+		}
 	}
 
-	var sig chan os.Signal
+	var ctx context.Context
 	{
-		sig = make(chan os.Signal, 1)
-		signal.Notify(sig, os.Interrupt)
+		var can context.CancelFunc
+		ctx, can = signal.NotifyContext(context.Background(), os.Interrupt)
+		defer can()
 	}
 
-	cli.OnConnectError = func(err error, socket gowebsocket.Socket) {
-		fmt.Println("Received connect error - ", err)
-	}
+	// Capture files get flushed once per second, so that at most the last
+	// second of messages gets lost if the process crashes or gets killed, and
+	// so that the current capture file can be read up to the last flush while
+	// it is still being written.
 
-	cli.OnConnected = func(socket gowebsocket.Socket) {
-		fmt.Println("Connected to server")
-	}
+	if dir != "" {
+		rec := capture.New(capture.Config{Dir: dir, Dur: time.Hour})
+		defer rec.Close()
 
-	cli.OnTextMessage = func(message string, socket gowebsocket.Socket) {
-		var err error
+		con.Rec = rec.Record
 
-		{
-			eth := strings.Contains(message, "ETH/USD")
-			snp := (strings.Contains(message, `"as"`) || strings.Contains(message, `"bs"`))
-			upd := (strings.Contains(message, `"a"`) || strings.Contains(message, `"b"`))
-
-			if !(eth && (snp || upd)) {
-				return
-			}
-		}
+		go flush(ctx, rec, time.Second)
+	}
 
-		var raw orderbook.Raw
-		{
-			message = strings.TrimPrefix(message, `[560,`)
-			message = strings.TrimSuffix(message, `,"book-10","ETH/USD"]`)
+	var cli *client.Client
+	{
+		cli = client.New(con)
+	}
 
-			err = json.Unmarshal([]byte(message), &raw)
-			if err != nil {
-				panic(err)
-			}
-		}
+	var don chan struct{}
+	{
+		don = make(chan struct{})
+	}
 
-		{
-			err = obk.Middleware(raw.Response())
-			if err != nil {
-				panic(err)
-			}
-		}
+	go func() {
+		defer close(don)
 
-		var byt []byte
-		{
-			byt, err = json.Marshal(obk)
+		for upd := range cli.Updates() {
+			byt, err := json.Marshal(upd.Book)
 			if err != nil {
-				panic(err)
+				fmt.Println("Cannot encode order book - ", err)
+				continue
 			}
-		}
 
-		{
 			fmt.Printf("%s\n", byt)
 		}
-	}
+	}()
 
-	cli.OnPingReceived = func(message string, socket gowebsocket.Socket) {
-		fmt.Println("Received ping - " + message)
-	}
-
-	cli.OnPongReceived = func(message string, socket gowebsocket.Socket) {
-		fmt.Println("Received pong - " + message)
+	{
+		err := cli.Run(ctx)
+		if err != nil {
+			fmt.Println("Socket Closed - ", err)
+		} else {
+			fmt.Println("Socket Closed")
+		}
 	}
 
-	cli.OnDisconnected = func(err error, socket gowebsocket.Socket) {
-		fmt.Println("Socket Closed")
+	{
+		<-don
 	}
+}
 
+// flush flushes the given recorder in the given interval until the given
+// context gets cancelled.
+func flush(ctx context.Context, rec *capture.Recorder, dur time.Duration) {
+	var tic *time.Ticker
 	{
-		cli.Connect()
-		cli.SendText(sub)
+		tic = time.NewTicker(dur)
+		defer tic.Stop()
 	}
 
-	for range sig {
-		cli.Close()
-		break
+	for {
+		select {
+		case <-tic.C:
+		case <-ctx.Done():
+			return
+		}
+
+		err := rec.Flush()
+		if err != nil {
+			fmt.Println("Cannot flush capture file - ", err)
+		}
 	}
 }
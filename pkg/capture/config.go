@@ -0,0 +1,30 @@
+package capture
+
+import "time"
+
+type Config struct {
+	// Dir is the directory capture files get written to. Dir must exist.
+	Dir string
+	// Dur is the maximum time span covered by a single capture file. Once
+	// exceeded, the next record starts a new file. Zero disables time based
+	// rotation.
+	Dur time.Duration
+	// Pre is the file name prefix of capture files. Defaults to "capture".
+	Pre string
+	// Siz is the maximum number of uncompressed bytes written to a single
+	// capture file. Once exceeded, the next record starts a new file. Zero
+	// disables size based rotation.
+	Siz int64
+}
+
+func (c Config) Verify() {
+	if c.Dir == "" {
+		panic("Config.Dir must not be empty")
+	}
+	if c.Dur < 0 {
+		panic("Config.Dur must not be negative")
+	}
+	if c.Siz < 0 {
+		panic("Config.Siz must not be negative")
+	}
+}
@@ -0,0 +1,143 @@
+package capture
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Record is a single raw websocket message together with its local receive
+// time. Records are encoded as JSON objects, keeping the message base64
+// encoded, so that every message gets captured byte by byte, including
+// malformed messages and messages which are not valid UTF-8. The websocket
+// library does not validate text messages, and encoding them as JSON string
+// would replace invalid UTF-8 with U+FFFD. Records written by earlier versions,
+// which kept the message as JSON string, can still be read.
+//
+//	{"byt":"eyJldmVudCI6ImhlYXJ0YmVhdCJ9","tim":"2022-12-01T13:40:00.950657Z"}
+type Record struct {
+	Message []byte
+	Time    time.Time
+}
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(record{Byt: r.Message, Tim: r.Time})
+}
+
+func (r *Record) UnmarshalJSON(byt []byte) error {
+	var rec record
+	{
+		err := json.Unmarshal(byt, &rec)
+		if err != nil {
+			return err
+		}
+	}
+
+	if rec.Byt == nil {
+		rec.Byt = []byte(rec.Msg)
+	}
+
+	{
+		r.Message = rec.Byt
+		r.Time = rec.Tim
+	}
+
+	return nil
+}
+
+// Reader reads the records of a single capture file in order.
+type Reader struct {
+	fil *os.File
+	gzr *gzip.Reader
+	sca *bufio.Scanner
+}
+
+// Open opens the given capture file for reading.
+func Open(pat string) (*Reader, error) {
+	var err error
+
+	var fil *os.File
+	{
+		fil, err = os.Open(pat)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open capture file: %w", err)
+		}
+	}
+
+	var gzr *gzip.Reader
+	{
+		gzr, err = gzip.NewReader(fil)
+		if err != nil {
+			fil.Close()
+			return nil, fmt.Errorf("cannot open capture file %s: %w", pat, err)
+		}
+	}
+
+	var sca *bufio.Scanner
+	{
+		sca = bufio.NewScanner(gzr)
+		sca.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	}
+
+	return &Reader{fil: fil, gzr: gzr, sca: sca}, nil
+}
+
+// Close closes the underlying capture file.
+func (r *Reader) Close() error {
+	return r.fil.Close()
+}
+
+// Next returns the next record, or io.EOF once all records got read. Capture
+// files that were not closed properly, e.g. because the recording process got
+// killed, end with io.ErrUnexpectedEOF after the last flushed record.
+func (r *Reader) Next() (Record, error) {
+	if !r.sca.Scan() {
+		if r.sca.Err() != nil {
+			return Record{}, fmt.Errorf("cannot read %s: %w", r.fil.Name(), r.sca.Err())
+		}
+
+		return Record{}, io.EOF
+	}
+
+	var rec Record
+	{
+		err := json.Unmarshal(r.sca.Bytes(), &rec)
+		if err != nil {
+			return Record{}, fmt.Errorf("cannot decode record of %s: %w", r.fil.Name(), err)
+		}
+	}
+
+	return rec, nil
+}
+
+// Files returns the paths of all capture files with the given prefix in the
+// given directory, in chronological order. An empty prefix defaults to
+// "capture", like Config.Pre.
+func Files(dir string, pre string) ([]string, error) {
+	if pre == "" {
+		pre = "capture"
+	}
+
+	pat, err := filepath.Glob(filepath.Join(dir, pre+"-*.jsonl.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	{
+		sort.Strings(pat)
+	}
+
+	return pat, nil
+}
+
+type record struct {
+	Byt []byte    `json:"byt,omitempty"`
+	Msg string    `json:"msg,omitempty"`
+	Tim time.Time `json:"tim"`
+}
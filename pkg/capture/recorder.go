@@ -0,0 +1,187 @@
+package capture
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Recorder writes raw websocket messages together with their local receive
+// time into gzip compressed JSON lines files. Files are only ever appended to
+// and get rotated according to Config.Dur and Config.Siz, so that finished
+// files can be processed while recording continues. File names carry the time
+// of their first record, which makes them sort chronologically.
+type Recorder struct {
+	con Config
+	fil *os.File
+	gzw *gzip.Writer
+	mut sync.Mutex
+	siz int64
+	tim time.Time
+}
+
+func New(con Config) *Recorder {
+	{
+		con.Verify()
+	}
+
+	if con.Pre == "" {
+		con.Pre = "capture"
+	}
+
+	return &Recorder{
+		con: con,
+	}
+}
+
+// Close finishes the current capture file. Recording again afterwards starts
+// a new file.
+func (r *Recorder) Close() error {
+	{
+		r.mut.Lock()
+		defer r.mut.Unlock()
+	}
+
+	return r.close()
+}
+
+// Flush writes all records compressed so far to the current capture file, so
+// that they can be read even if the process terminates before Close gets
+// called.
+func (r *Recorder) Flush() error {
+	{
+		r.mut.Lock()
+		defer r.mut.Unlock()
+	}
+
+	if r.gzw == nil {
+		return nil
+	}
+
+	return r.gzw.Flush()
+}
+
+// Record appends the given message received at the given time. Record matches
+// the signature of client.Config.Rec and is safe for concurrent use.
+func (r *Recorder) Record(tim time.Time, byt []byte) error {
+	var err error
+
+	{
+		r.mut.Lock()
+		defer r.mut.Unlock()
+	}
+
+	var lin []byte
+	{
+		lin, err = json.Marshal(Record{Message: byt, Time: tim})
+		if err != nil {
+			return err
+		}
+
+		lin = append(lin, '\n')
+	}
+
+	if r.rotate(tim) {
+		err = r.close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.gzw == nil {
+		err = r.open(tim)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		_, err = r.gzw.Write(lin)
+		if err != nil {
+			return fmt.Errorf("cannot write to %s: %w", r.fil.Name(), err)
+		}
+	}
+
+	{
+		r.siz += int64(len(lin))
+	}
+
+	return nil
+}
+
+func (r *Recorder) close() error {
+	if r.gzw == nil {
+		return nil
+	}
+
+	var err error
+	{
+		err = r.gzw.Close()
+		if err != nil {
+			r.fil.Close()
+		} else {
+			err = r.fil.Close()
+		}
+	}
+
+	{
+		r.fil = nil
+		r.gzw = nil
+		r.siz = 0
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot close capture file: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) open(tim time.Time) error {
+	var err error
+
+	var pat string
+	{
+		pat = filepath.Join(r.con.Dir, fmt.Sprintf("%s-%s.jsonl.gz", r.con.Pre, tim.UTC().Format(layout)))
+	}
+
+	{
+		r.fil, err = os.OpenFile(pat, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return fmt.Errorf("cannot create capture file: %w", err)
+		}
+	}
+
+	{
+		r.gzw = gzip.NewWriter(r.fil)
+		r.tim = tim
+	}
+
+	return nil
+}
+
+// rotate returns whether the current capture file is full, given a record
+// received at the given time is about to be written.
+func (r *Recorder) rotate(tim time.Time) bool {
+	if r.gzw == nil {
+		return false
+	}
+
+	if r.con.Siz != 0 && r.siz >= r.con.Siz {
+		return true
+	}
+
+	if r.con.Dur != 0 && tim.Sub(r.tim) >= r.con.Dur {
+		return true
+	}
+
+	return false
+}
+
+// layout is the time format of capture file names. The fixed width keeps the
+// lexical order of file names chronological.
+const layout = "20060102T150405.000000000Z"
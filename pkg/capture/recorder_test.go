@@ -0,0 +1,196 @@
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+// Test_Recorder_Record ensures that all records can be read back in order,
+// across rotated capture files.
+func Test_Recorder_Record(t *testing.T) {
+	testCases := []struct {
+		con Config
+		fil int
+	}{
+		{con: Config{}, fil: 1},
+		{con: Config{Siz: 200}, fil: 5},
+		{con: Config{Dur: 3 * time.Second}, fil: 4},
+		{con: Config{Dur: 3 * time.Second, Pre: "book", Siz: 1}, fil: 10},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			{
+				tc.con.Dir = t.TempDir()
+			}
+
+			var rec *Recorder
+			{
+				rec = New(tc.con)
+			}
+
+			for _, x := range testdata() {
+				err := rec.Record(x.Time, x.Message)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			{
+				err := rec.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var pat []string
+			{
+				pat = files(t, tc.con)
+			}
+
+			if len(pat) != tc.fil {
+				t.Fatalf("expected %d capture files, got %d", tc.fil, len(pat))
+			}
+
+			var all []Record
+			for _, x := range pat {
+				rea, err := Open(x)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				all = append(all, read(t, rea, io.EOF)...)
+
+				rea.Close()
+			}
+
+			equal(t, all, testdata())
+		})
+	}
+}
+
+// Test_Recorder_Flush ensures that flushed records of a capture file that got
+// never closed can be read, followed by io.ErrUnexpectedEOF.
+func Test_Recorder_Flush(t *testing.T) {
+	var con Config
+	{
+		con = Config{Dir: t.TempDir()}
+	}
+
+	var rec *Recorder
+	{
+		rec = New(con)
+	}
+
+	for _, x := range testdata()[:5] {
+		err := rec.Record(x.Time, x.Message)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	{
+		err := rec.Flush()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rea *Reader
+	{
+		var err error
+
+		rea, err = Open(files(t, con)[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rea.Close()
+	}
+
+	equal(t, read(t, rea, io.ErrUnexpectedEOF), testdata()[:5])
+
+	{
+		rec.Close()
+	}
+}
+
+// Test_Record_UnmarshalJSON ensures that records written by earlier versions,
+// which kept the message as JSON string, can still be read.
+func Test_Record_UnmarshalJSON(t *testing.T) {
+	var rec Record
+	{
+		err := json.Unmarshal([]byte(`{"msg":"{\"event\":\"heartbeat\"}","tim":"2022-12-01T13:40:00.950657Z"}`), &rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if string(rec.Message) != `{"event":"heartbeat"}` || rec.Time.Nanosecond() != 950657000 {
+		t.Fatalf("expected heartbeat at 13:40:00.950657, got %s at %s", rec.Message, rec.Time)
+	}
+}
+
+func equal(t *testing.T, act []Record, exp []Record) {
+	if len(act) != len(exp) {
+		t.Fatalf("expected %d records, got %d", len(exp), len(act))
+	}
+
+	for i := range exp {
+		if string(act[i].Message) != string(exp[i].Message) || !act[i].Time.Equal(exp[i].Time) {
+			t.Fatalf("record %d: expected %s at %s, got %s at %s", i, exp[i].Message, exp[i].Time, act[i].Message, act[i].Time)
+		}
+	}
+}
+
+func files(t *testing.T, con Config) []string {
+	pat, err := Files(con.Dir, con.Pre)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pat
+}
+
+// read returns all records of the given reader and requires the reader to end
+// with the given error.
+func read(t *testing.T, rea *Reader, end error) []Record {
+	var all []Record
+
+	for {
+		rec, err := rea.Next()
+		if errors.Is(err, end) {
+			return all
+		} else if err != nil {
+			t.Fatal(err)
+		}
+
+		all = append(all, rec)
+	}
+}
+
+// testdata returns ten records received one second apart, including a
+// malformed message which is not valid UTF-8.
+func testdata() []Record {
+	var tim time.Time
+	{
+		tim = time.Date(2022, 12, 1, 13, 40, 0, 950657000, time.UTC)
+	}
+
+	var all []Record
+	for i := 0; i < 9; i++ {
+		all = append(all, Record{
+			Message: []byte(fmt.Sprintf(`[560,{"a":[["1272.70000","%d.00000000","1669902400.950657"]],"c":"%d"},"book-10","ETH/USD"]`, i+1, i)),
+			Time:    tim.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	{
+		all = append(all, Record{Message: []byte("not \"json\"\n\xff\xfe"), Time: tim.Add(9 * time.Second)})
+	}
+
+	return all
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoff returns the delay before the given connection attempt, counting from
+// zero. The delay grows exponentially from ini up to lim. Jitter spreads the
+// actual delay between half and all of the computed delay, so that many
+// clients disconnected at once do not reconnect at the exact same time.
+func backoff(ini time.Duration, lim time.Duration, att int) time.Duration {
+	var del time.Duration
+	{
+		del = ini
+	}
+
+	for i := 0; i < att && del < lim; i++ {
+		del *= 2
+	}
+
+	if del > lim {
+		del = lim
+	}
+
+	if del <= 1 {
+		return del
+	}
+
+	return del/2 + time.Duration(rand.Int63n(int64(del/2)+1))
+}
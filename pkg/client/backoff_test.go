@@ -0,0 +1,29 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_Client_backoff(t *testing.T) {
+	testCases := []struct {
+		att int
+		low time.Duration
+		upp time.Duration
+	}{
+		{att: 0, low: 500 * time.Millisecond, upp: time.Second},
+		{att: 1, low: time.Second, upp: 2 * time.Second},
+		{att: 3, low: 4 * time.Second, upp: 8 * time.Second},
+		{att: 10, low: 30 * time.Second, upp: time.Minute},
+		{att: 1000, low: 30 * time.Second, upp: time.Minute},
+	}
+
+	for i, tc := range testCases {
+		for j := 0; j < 100; j++ {
+			del := backoff(time.Second, time.Minute, tc.att)
+			if del < tc.low || del > tc.upp {
+				t.Fatalf("test case %d: expected delay between %s and %s, got %s", i, tc.low, tc.upp, del)
+			}
+		}
+	}
+}
@@ -0,0 +1,743 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Update is delivered for every book message that got applied to an order
+// book and passed checksum verification.
+type Update struct {
+	// Book is the order book the update got applied to. Book is shared with
+	// the Client and must only be read using its query API.
+	Book *orderbook.Orderbook
+	// Pair is the asset pair of the order book, e.g. "ETH/USD".
+	Pair string
+	// Response is the book message that got applied.
+	Response orderbook.Response
+}
+
+// Metrics are counters describing the health of the book stream, summed up
+// over all pairs.
+type Metrics struct {
+	// ChecksumErrors is the number of checksum mismatches detected.
+	ChecksumErrors uint64
+	// Reconnects is the number of connection attempts after the initial one.
+	Reconnects uint64
+	// Resyncs is the number of times an order book got rebuilt from a fresh
+	// snapshot after a checksum mismatch or an invalid book message.
+	Resyncs uint64
+	// Stalls is the number of times the watchdog considered the feed stale and
+	// forced a reconnection.
+	Stalls uint64
+}
+
+// Client streams Kraken book subscriptions of many pairs over a single
+// connection, maintaining one orderbook.Orderbook per pair.
+type Client struct {
+	blk atomic.Bool
+	che atomic.Uint64
+	con Config
+	dis orderbook.Dispatcher
+	fat error
+	hbt atomic.Int64
+	mut sync.Mutex
+	rdy atomic.Int64
+	rec atomic.Uint64
+	reg map[string]*pair
+	res atomic.Uint64
+	soc *websocket.Conn
+	stl atomic.Uint64
+	upd chan Update
+	wri sync.Mutex
+}
+
+func New(con Config) *Client {
+	{
+		con.Verify()
+	}
+
+	if con.Max == 0 {
+		con.Max = time.Minute
+	}
+	if con.Min == 0 {
+		con.Min = time.Second
+	}
+	if con.Min > con.Max {
+		con.Min = con.Max
+	}
+	if con.Sta == 0 {
+		con.Sta = 10 * time.Second
+	}
+
+	var cli *Client
+	{
+		cli = &Client{
+			con: con,
+			reg: map[string]*pair{},
+			upd: make(chan Update, con.Buf),
+		}
+	}
+
+	for _, x := range con.Pai {
+		cli.reg[x] = cli.pair()
+	}
+
+	{
+		cli.dis = orderbook.Dispatcher{
+			OnError: func(evn orderbook.ErrorEvent) {
+				cli.error(evn)
+			},
+			OnHeartbeat: func(evn orderbook.Heartbeat) {
+				cli.hbt.Store(time.Now().UnixNano())
+			},
+			OnSubscriptionStatus: func(evn orderbook.SubscriptionStatus) {
+				cli.fat = cli.status(evn)
+			},
+			OnSystemStatus: func(evn orderbook.SystemStatus) {
+				if !evn.Online() {
+					cli.error(fmt.Errorf("system status must be online, got %s", evn.Status))
+				}
+			},
+		}
+	}
+
+	return cli
+}
+
+// Add starts maintaining an order book for the given pair. If the Client is
+// connected, the pair gets subscribed to right away. Otherwise the pair gets
+// subscribed to once connected. Adding a pair twice has no effect.
+func (c *Client) Add(pai string) {
+	if pai == "" {
+		panic("pair must not be empty")
+	}
+
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	if c.reg[pai] != nil {
+		return
+	}
+
+	{
+		c.reg[pai] = c.pair()
+	}
+
+	{
+		c.send("subscribe", pai)
+	}
+}
+
+// Book returns the order book maintained for the given pair, or nil if the pair
+// is not part of the Client.
+func (c *Client) Book(pai string) *orderbook.Orderbook {
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	if c.reg[pai] == nil {
+		return nil
+	}
+
+	return c.reg[pai].obk
+}
+
+// Heartbeat returns the time the last heartbeat got received, or the zero time
+// if none got received yet. Kraken sends heartbeats once per second if no other
+// messages got sent.
+func (c *Client) Heartbeat() time.Time {
+	if c.hbt.Load() == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, c.hbt.Load())
+}
+
+// Live returns whether the order book of the given pair is valid and changed
+// within Config.Sta. See orderbook.Orderbook.Live.
+func (c *Client) Live(pai string) bool {
+	var obk *orderbook.Orderbook
+	{
+		obk = c.Book(pai)
+		if obk == nil {
+			return false
+		}
+	}
+
+	return obk.Live(c.con.Sta)
+}
+
+// Metrics returns the current counters of the book stream.
+func (c *Client) Metrics() Metrics {
+	return Metrics{
+		ChecksumErrors: c.che.Load(),
+		Reconnects:     c.rec.Load(),
+		Resyncs:        c.res.Load(),
+		Stalls:         c.stl.Load(),
+	}
+}
+
+// Pairs returns all pairs the Client maintains order books for, sorted
+// alphabetically.
+func (c *Client) Pairs() []string {
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	var pai []string
+	for k := range c.reg {
+		pai = append(pai, k)
+	}
+
+	{
+		sort.Strings(pai)
+	}
+
+	return pai
+}
+
+// Remove stops maintaining the order book of the given pair and unsubscribes
+// from it if the Client is connected. Book messages of the pair still in flight
+// get ignored. Removing an unknown pair has no effect.
+func (c *Client) Remove(pai string) {
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	if c.reg[pai] == nil {
+		return
+	}
+
+	{
+		delete(c.reg, pai)
+	}
+
+	{
+		c.send("unsubscribe", pai)
+	}
+}
+
+// Run connects to the configured websocket API, subscribes to the book channel
+// of all pairs and applies every received book message to the order book of its
+// pair. Verified updates are delivered via Client.Updates. On checksum
+// mismatch, or once a book message of a pair is malformed or invalid, the
+// affected order book gets reset and rebuilt by unsubscribing and subscribing
+// again, as recommended by Kraken, while all other pairs keep streaming. Once
+// the connection drops, Run reconnects with exponential backoff as configured
+// via Config.Min and Config.Max, subscribes again and discards the stale order
+// book state until new snapshots arrive. The same applies once the feed is
+// considered stale as configured via Config.Sta. Pairs Kraken rejects the
+// subscription of get removed and reported via Config.Err. Run blocks until the
+// given context gets cancelled or Config.Ret consecutive connection attempts
+// failed. Run closes the updates channel before returning and must therefore
+// only be called once. The returned error is nil if Run returned due to context
+// cancellation.
+func (c *Client) Run(ctx context.Context) error {
+	{
+		defer close(c.upd)
+	}
+
+	var att int
+	for {
+		var sta time.Time
+		{
+			sta = time.Now()
+		}
+
+		rec, err := c.session(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !rec {
+			return err
+		}
+
+		// Only a session that stayed up for at least Config.Max resets the
+		// backoff. A server accepting connections and dropping them right away
+		// must increase the delay between attempts just like a server refusing
+		// connections does.
+
+		if errors.Is(err, errDisconnected) && time.Since(sta) >= c.con.Max {
+			att = 0
+		} else {
+			att++
+		}
+
+		if c.con.Ret != 0 && att >= c.con.Ret {
+			return err
+		}
+
+		{
+			c.error(err)
+		}
+
+		select {
+		case <-time.After(backoff(c.con.Min, c.con.Max, att)):
+		case <-ctx.Done():
+			return nil
+		}
+
+		{
+			c.rec.Add(1)
+		}
+	}
+}
+
+// Updates returns the channel verified book updates are delivered on. The
+// channel gets closed once Client.Run returns. The consumer must keep reading
+// from the channel, since messages are not processed while the channel is
+// full. A slow consumer does not cause reconnects, but order books lag behind
+// the market until the consumer catches up.
+func (c *Client) Updates() <-chan Update {
+	return c.upd
+}
+
+func (c *Client) error(err error) {
+	if c.con.Err != nil {
+		c.con.Err(err)
+	}
+}
+
+// lookup returns the state of the given pair, or nil if the pair is not part of
+// the Client.
+func (c *Client) lookup(pai string) *pair {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.reg[pai]
+}
+
+// message processes a single text message. message returns an error only if
+// the stream cannot continue, while all other errors are reported via
+// Config.Err.
+func (c *Client) message(ctx context.Context, byt []byte) error {
+	var err error
+
+	var msg orderbook.Message
+	{
+		msg, err = orderbook.Decode(byt)
+		if err != nil {
+			c.error(fmt.Errorf("received malformed message: %w", err))
+			return nil
+		}
+	}
+
+	if msg.Event != nil {
+		err = c.dis.Dispatch(*msg.Event)
+		if err != nil {
+			c.error(fmt.Errorf("received malformed event: %w", err))
+		}
+
+		return c.fat
+	}
+
+	if !msg.Frame.Book() {
+		return nil
+	}
+
+	var pai *pair
+	{
+		pai = c.lookup(msg.Frame.Pair)
+		if pai == nil {
+			return nil
+		}
+	}
+
+	var rsp orderbook.Response
+	{
+		rsp, err = msg.Frame.Response()
+		if err != nil {
+			c.resync(msg.Frame.Pair, pai, fmt.Errorf("received malformed message: %w", err))
+			return nil
+		}
+	}
+
+	// While resyncing or reconnecting, all book messages that are still in
+	// flight get dropped. Only a fresh snapshot can make the order book valid
+	// again.
+
+	if !pai.obk.Valid() && !rsp.IsSnapshot {
+		return nil
+	}
+
+	// Any book message that cannot be applied, be it due to a checksum
+	// mismatch or due to invalid price levels, leaves the order book of its
+	// pair in an unknown state, while all other pairs are not affected.
+
+	{
+		err = pai.obk.Middleware(rsp)
+		if err != nil {
+			c.resync(msg.Frame.Pair, pai, err)
+			return nil
+		}
+	}
+
+	if pai.rsy {
+		pai.rsy = false
+		c.res.Add(1)
+	}
+
+	var upd Update
+	{
+		upd = Update{Book: pai.obk, Pair: msg.Frame.Pair, Response: rsp}
+	}
+
+	select {
+	case c.upd <- upd:
+		return nil
+	default:
+	}
+
+	// The consumer does not keep up. No messages get read while we wait, so
+	// the watchdog must not mistake the resulting silence for a dead feed.
+
+	{
+		c.blk.Store(true)
+	}
+
+	select {
+	case c.upd <- upd:
+	case <-ctx.Done():
+	}
+
+	{
+		c.rdy.Store(time.Now().UnixNano())
+		c.blk.Store(false)
+	}
+
+	return nil
+}
+
+// pair returns the initial state of a newly added pair. The order book gets
+// reset right away, so that the watchdog measures the time until its first
+// snapshot.
+func (c *Client) pair() *pair {
+	var obk *orderbook.Orderbook
+	{
+		obk = orderbook.New(orderbook.Config{Dep: c.con.Dep})
+		obk.Reset()
+	}
+
+	return &pair{
+		obk: obk,
+	}
+}
+
+// resync marks the order book of the given pair as invalid and unsubscribes
+// from the broken subscription. The subscription gets renewed once Kraken
+// answers the unsubscription. Errors while the unsubscription is outstanding
+// belong to the broken subscription and get only reported, while errors
+// afterwards, e.g. an invalid snapshot of the renewed subscription, start
+// another resync.
+func (c *Client) resync(key string, pai *pair, err error) {
+	if errors.As(err, new(*orderbook.ChecksumError)) {
+		c.che.Add(1)
+	}
+
+	{
+		c.error(fmt.Errorf("resyncing order book of %s: %w", key, err))
+	}
+
+	if pai.uns {
+		return
+	}
+
+	{
+		pai.obk.Reset()
+		pai.rsy = true
+		pai.uns = true
+	}
+
+	{
+		c.send("unsubscribe", key)
+	}
+}
+
+// send writes a subscribe or unsubscribe request for the book channel of the
+// given pairs. Without connection, send does nothing, since all pairs get
+// subscribed to once connected. Write errors are only reported via Config.Err,
+// since a broken connection gets detected and recovered by Client.session.
+func (c *Client) send(evn string, pai ...string) {
+	c.wri.Lock()
+	defer c.wri.Unlock()
+
+	if c.soc == nil || len(pai) == 0 {
+		return
+	}
+
+	err := c.soc.WriteJSON(request{
+		Event:        evn,
+		Pair:         pai,
+		Subscription: orderbook.Subscription{Name: "book", Depth: c.con.Dep},
+	})
+	if err != nil {
+		c.error(fmt.Errorf("cannot %s to %s: %w", evn, c.con.API, err))
+	}
+}
+
+// session connects, subscribes and processes messages until the connection
+// drops. session returns whether reconnecting may recover from the returned
+// error.
+func (c *Client) session(ctx context.Context) (bool, error) {
+	var err error
+
+	var can context.CancelCauseFunc
+	{
+		ctx, can = context.WithCancelCause(ctx)
+		defer can(nil)
+	}
+
+	var con *websocket.Conn
+	{
+		con, _, err = websocket.DefaultDialer.DialContext(ctx, c.con.API, nil)
+		if err != nil {
+			return true, fmt.Errorf("cannot connect to %s: %w", c.con.API, err)
+		}
+		defer con.Close()
+	}
+
+	// Any order book state of a previous connection is stale, since updates
+	// got lost while disconnected. The order books remain invalid until the
+	// new subscriptions deliver snapshots. Registering the connection and
+	// subscribing happens under the registry lock, so that pairs added or
+	// removed concurrently get subscribed to exactly once.
+
+	{
+		c.mut.Lock()
+
+		var pai []string
+		for k, v := range c.reg {
+			v.obk.Reset()
+			v.rsy = false
+			v.uns = false
+			pai = append(pai, k)
+		}
+
+		sort.Strings(pai)
+
+		c.wri.Lock()
+		c.soc = con
+		c.wri.Unlock()
+
+		c.send("subscribe", pai...)
+
+		c.mut.Unlock()
+	}
+
+	defer func() {
+		c.wri.Lock()
+		c.soc = nil
+		c.wri.Unlock()
+	}()
+
+	{
+		c.rdy.Store(time.Now().UnixNano())
+	}
+
+	go c.watchdog(ctx, can)
+
+	// Closing the connection is the only way to interrupt a blocking read. So
+	// once the context gets cancelled, we close the connection gracefully and
+	// let the read loop below return.
+
+	go func() {
+		<-ctx.Done()
+		c.wri.Lock()
+		con.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		c.wri.Unlock()
+		con.Close()
+	}()
+
+	for {
+		var byt []byte
+		{
+			_, byt, err = con.ReadMessage()
+			if context.Cause(ctx) != nil {
+				return true, context.Cause(ctx)
+			}
+			if err != nil {
+				return true, fmt.Errorf("%w from %s: %w", errDisconnected, c.con.API, err)
+			}
+		}
+
+		var now time.Time
+		{
+			now = time.Now()
+		}
+
+		if c.con.Rec != nil {
+			err = c.con.Rec(now, byt)
+			if err != nil {
+				c.error(fmt.Errorf("cannot record message: %w", err))
+			}
+		}
+
+		{
+			err = c.message(ctx, byt)
+			if err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+// status handles subscription status events. A rejected subscription removes
+// the pair, while the unsubscription of a resyncing pair renews its
+// subscription in order to receive a fresh snapshot, regardless of whether
+// Kraken confirmed or rejected the unsubscription. Only errors which cannot be
+// attributed to any pair are returned.
+func (c *Client) status(evn orderbook.SubscriptionStatus) error {
+	if evn.Pair == "" {
+		return evn.Err()
+	}
+
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	var pai *pair
+	{
+		pai = c.reg[evn.Pair]
+		if pai == nil {
+			return nil
+		}
+	}
+
+	// A rejected unsubscription, e.g. because Kraken does not know about the
+	// subscription anymore, must not cost us the pair, since all we want is a
+	// fresh snapshot.
+
+	if pai.uns && (evn.Err() != nil || evn.Status == "unsubscribed") {
+		if evn.Err() != nil {
+			c.error(fmt.Errorf("cannot unsubscribe from %s: %w", evn.Pair, evn.Err()))
+		}
+
+		pai.uns = false
+		c.send("subscribe", evn.Pair)
+
+		return nil
+	}
+
+	if evn.Err() != nil {
+		delete(c.reg, evn.Pair)
+		c.error(fmt.Errorf("cannot subscribe to %s: %w", evn.Pair, evn.Err()))
+		return nil
+	}
+
+	return nil
+}
+
+// watchdog periodically checks the feed for staleness and cancels the session
+// once the feed is considered stale, which causes Client.Run to reconnect.
+func (c *Client) watchdog(ctx context.Context, can context.CancelCauseFunc) {
+	var tic *time.Ticker
+	{
+		tic = time.NewTicker(c.con.Sta / 4)
+		defer tic.Stop()
+	}
+
+	for {
+		select {
+		case <-tic.C:
+		case <-ctx.Done():
+			return
+		}
+
+		err := c.stale()
+		if err != nil {
+			c.stl.Add(1)
+			can(fmt.Errorf("%w from %s: %w", errDisconnected, c.con.API, err))
+			return
+		}
+	}
+}
+
+// stale returns an error describing why the feed is considered stale, or nil
+// if it is not. The feed is alive as long as heartbeats arrive or any order
+// book changes, measured from the time reading started, or resumed after the
+// consumer of Client.Updates caught up. Order books that are valid but did not
+// change recently are not considered stale, since they may just belong to a
+// quiet market, which Kraken keeps sending heartbeats for. While the consumer
+// does not keep up, the feed is never considered stale.
+func (c *Client) stale() error {
+	if c.blk.Load() {
+		return nil
+	}
+
+	var rdy time.Time
+	var las time.Time
+	{
+		rdy = time.Unix(0, c.rdy.Load())
+		las = rdy
+	}
+
+	if c.Heartbeat().After(las) {
+		las = c.Heartbeat()
+	}
+
+	{
+		c.mut.Lock()
+		defer c.mut.Unlock()
+	}
+
+	for k, v := range c.reg {
+		var chg time.Time
+		{
+			chg = time.Now().Add(-v.obk.Age())
+		}
+
+		if chg.After(las) {
+			las = chg
+		}
+
+		if !v.obk.Valid() && time.Since(chg) > c.con.Sta && time.Since(rdy) > c.con.Sta {
+			return fmt.Errorf("no snapshot received for %s within %s", k, c.con.Sta)
+		}
+	}
+
+	if time.Since(las) > c.con.Sta {
+		return fmt.Errorf("no message received within %s", c.con.Sta)
+	}
+
+	return nil
+}
+
+// errDisconnected is returned by Client.session once an established connection
+// dropped.
+var errDisconnected = errors.New("disconnected")
+
+// pair is the state the Client maintains for every pair.
+type pair struct {
+	// obk is the order book of the pair.
+	obk *orderbook.Orderbook
+	// rsy is whether the order book is being rebuilt after a checksum
+	// mismatch or an invalid book message, waiting for the unsubscription to
+	// be confirmed and for a fresh snapshot to arrive.
+	rsy bool
+	// uns is whether the unsubscription of a resyncing pair got requested,
+	// but not answered yet.
+	uns bool
+}
+
+// request is a subscribe or unsubscribe request of the Kraken websocket API
+// v1.
+type request struct {
+	Event        string                 `json:"event"`
+	Pair         []string               `json:"pair"`
+	Subscription orderbook.Subscription `json:"subscription"`
+}
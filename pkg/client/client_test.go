@@ -0,0 +1,909 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phoebetronic/orderbook-kraken/pkg/mock"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Test_Client_Run aims to cover the whole streaming process against a local
+// mock server, from subscribing to delivering verified updates, until the
+// context gets cancelled.
+func Test_Client_Run(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD", "XBT/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Pai: []string{"ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if !upd.Response.IsSnapshot {
+			t.Fatal("expected first update to be a snapshot")
+		}
+	}
+
+	{
+		srv.Update("XBT/USD", levels("1272.70000", "0.50000000"), nil)
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), levels("1271.81000", "3.25000000"))
+		srv.Update("ETH/USD", levels("1272.71000", "0.00000000", "1273.36000", "1.00000000"), levels("1271.79000", "0.10000000"))
+	}
+
+	for i := 0; i < 2; i++ {
+		upd := <-cli.Updates()
+		if upd.Pair != "ETH/USD" {
+			t.Fatalf("expected pair ETH/USD, got %s", upd.Pair)
+		}
+		if upd.Book != cli.Book("ETH/USD") {
+			t.Fatal("expected update to reference the client order book")
+		}
+		if upd.Response.IsSnapshot {
+			t.Fatalf("update %d: expected update not to be a snapshot", i)
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	{
+		_, ok := <-cli.Updates()
+		if ok {
+			t.Fatal("expected updates channel to be closed")
+		}
+	}
+
+	bid, _ := cli.Book("ETH/USD").BestBid()
+	if bid.Price.String() != "1271.81000" {
+		t.Fatalf("expected best bid 1271.81000, got %s", bid.Price)
+	}
+}
+
+// Test_Client_Run_Checksum ensures that a checksum mismatch causes the client
+// to unsubscribe and subscribe again, dropping in flight updates of the broken
+// subscription, until the order book got rebuilt from a fresh snapshot.
+func Test_Client_Run_Checksum(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Pai: []string{"ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+	}
+
+	{
+		srv.Corrupt("ETH/USD")
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), levels("1271.81000", "3.25000000"))
+		srv.Update("ETH/USD", levels("1272.71000", "0.00000000", "1273.36000", "1.00000000"), levels("1271.79000", "0.10000000"))
+	}
+
+	{
+		upd := <-cli.Updates()
+		if !upd.Response.IsSnapshot {
+			t.Fatal("expected updates of the broken subscription to be dropped")
+		}
+	}
+
+	{
+		srv.Update("ETH/USD", nil, levels("1271.82000", "1.00000000"))
+	}
+
+	{
+		upd := <-cli.Updates()
+		if upd.Response.IsSnapshot {
+			t.Fatal("expected update after resync")
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !cli.Book("ETH/USD").Valid() {
+		t.Fatal("expected order book to be valid after resync")
+	}
+
+	met := cli.Metrics()
+	if met.ChecksumErrors != 1 || met.Resyncs != 1 {
+		t.Fatalf("expected 1 checksum error and 1 resync, got %#v", met)
+	}
+
+	var evn []string
+	for _, x := range srv.Requests() {
+		evn = append(evn, x.Event)
+	}
+
+	if strings.Join(evn, ",") != "subscribe,unsubscribe,subscribe" {
+		t.Fatalf("expected subscribe, unsubscribe and subscribe requests, got %v", evn)
+	}
+
+	bid, _ := cli.Book("ETH/USD").BestBid()
+	if bid.Price.String() != "1271.82000" {
+		t.Fatalf("expected best bid 1271.82000, got %s", bid.Price)
+	}
+}
+
+// Test_Client_Run_Crossed ensures that an invalid snapshot only causes the
+// order book of its own pair to be rebuilt, while all other pairs keep
+// streaming.
+func Test_Client_Run_Crossed(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD", "XBT/USD")
+		srv.Cross("XBT/USD")
+		defer srv.Close()
+	}
+
+	var erc chan error
+	{
+		erc = make(chan error, 10)
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Err: report(erc), Pai: []string{"ETH/USD", "XBT/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	var snp []string
+	for len(snp) < 2 {
+		upd := <-cli.Updates()
+		snp = append(snp, upd.Pair)
+	}
+
+	{
+		err := <-erc
+		if !strings.Contains(err.Error(), "resyncing order book of XBT/USD") || !strings.Contains(err.Error(), "must be below best ask") {
+			t.Fatalf("expected resync of XBT/USD due to crossed snapshot, got %v", err)
+		}
+	}
+
+	{
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), nil)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if upd.Pair != "ETH/USD" || upd.Response.IsSnapshot {
+			t.Fatalf("expected update for ETH/USD, got %s", upd.Pair)
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if strings.Join(snp, ",") != "ETH/USD,XBT/USD" {
+		t.Fatalf("expected snapshots for ETH/USD and XBT/USD, got %v", snp)
+	}
+
+	met := cli.Metrics()
+	if met.ChecksumErrors != 0 || met.Resyncs != 1 || met.Reconnects != 0 {
+		t.Fatalf("expected 0 checksum errors, 1 resync and 0 reconnects, got %#v", met)
+	}
+
+	if !cli.Book("XBT/USD").Valid() {
+		t.Fatal("expected order book of XBT/USD to be valid after resync")
+	}
+}
+
+// Test_Client_Run_Crossed_Twice ensures that an invalid snapshot of a renewed
+// subscription starts another resync instead of leaving the order book invalid
+// until the watchdog reconnects.
+func Test_Client_Run_Crossed_Twice(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD", "XBT/USD")
+		srv.Cross("XBT/USD")
+		srv.Cross("XBT/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Pai: []string{"ETH/USD", "XBT/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	var snp []string
+	for len(snp) < 2 {
+		select {
+		case upd := <-cli.Updates():
+			snp = append(snp, upd.Pair)
+		case <-time.After(time.Second):
+			t.Fatalf("expected snapshots for ETH/USD and XBT/USD, got %v", snp)
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var evn []string
+	for _, x := range srv.Requests() {
+		evn = append(evn, x.Event)
+	}
+
+	if strings.Join(evn, ",") != "subscribe,unsubscribe,subscribe,unsubscribe,subscribe" {
+		t.Fatalf("expected two resync cycles, got %v", evn)
+	}
+
+	met := cli.Metrics()
+	if met.Resyncs != 1 || met.Reconnects != 0 {
+		t.Fatalf("expected 1 resync and 0 reconnects, got %#v", met)
+	}
+
+	if !cli.Book("ETH/USD").Valid() || !cli.Book("XBT/USD").Valid() {
+		t.Fatal("expected order books of ETH/USD and XBT/USD to be valid")
+	}
+}
+
+// Test_Client_Run_Unsubscribe ensures that a rejected unsubscription during a
+// resync keeps the pair and subscribes to it again.
+func Test_Client_Run_Unsubscribe(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var erc chan error
+	{
+		erc = make(chan error, 10)
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Err: report(erc), Pai: []string{"ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+	}
+
+	{
+		srv.Corrupt("ETH/USD")
+		srv.Lose("ETH/USD")
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), nil)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if !upd.Response.IsSnapshot {
+			t.Fatal("expected snapshot after resync")
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if cli.Book("ETH/USD") == nil || !cli.Book("ETH/USD").Valid() {
+		t.Fatal("expected order book to be kept and valid after resync")
+	}
+
+	var msg []string
+	for len(erc) != 0 {
+		msg = append(msg, (<-erc).Error())
+	}
+
+	if !strings.Contains(strings.Join(msg, ";"), "cannot unsubscribe from ETH/USD") {
+		t.Fatalf("expected unsubscribe error to be reported, got %v", msg)
+	}
+
+	var evn []string
+	for _, x := range srv.Requests() {
+		evn = append(evn, x.Event)
+	}
+
+	if strings.Join(evn, ",") != "subscribe,unsubscribe,subscribe" {
+		t.Fatalf("expected subscribe, unsubscribe and subscribe requests, got %v", evn)
+	}
+}
+
+func Test_Client_Run_Connect(t *testing.T) {
+	var cli *Client
+	{
+		cli = New(Config{API: "ws://127.0.0.1:1", Dep: 10, Min: time.Millisecond, Pai: []string{"ETH/USD"}, Ret: 3})
+	}
+
+	err := cli.Run(context.Background())
+	if err == nil {
+		t.Fatal("expected connection failure to cause an error")
+	}
+
+	if cli.Metrics().Reconnects != 2 {
+		t.Fatalf("expected 2 reconnects, got %d", cli.Metrics().Reconnects)
+	}
+}
+
+// Test_Client_Run_Error ensures that Kraken error events get reported without
+// ending the stream.
+func Test_Client_Run_Error(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var erc chan error
+	{
+		erc = make(chan error, 1)
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Err: report(erc), Pai: []string{"ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+		srv.Error("Unsupported event")
+	}
+
+	{
+		err := <-erc
+		if err.Error() != "kraken error: Unsupported event" {
+			t.Fatalf("expected kraken error, got %v", err)
+		}
+	}
+
+	{
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), nil)
+		<-cli.Updates()
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Test_Client_Run_Pairs ensures that book messages get routed to the order
+// book of their pair, and that pairs can be added and removed at runtime.
+func Test_Client_Run_Pairs(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD", "LTC/USD", "XBT/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Pai: []string{"XBT/USD", "ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	var pai []string
+	for i := 0; i < 2; i++ {
+		upd := <-cli.Updates()
+		if upd.Book != cli.Book(upd.Pair) {
+			t.Fatalf("update %d: expected update to reference the order book of %s", i, upd.Pair)
+		}
+		pai = append(pai, upd.Pair)
+	}
+
+	if !strings.Contains(strings.Join(pai, ","), "ETH/USD") || !strings.Contains(strings.Join(pai, ","), "XBT/USD") {
+		t.Fatalf("expected snapshots for ETH/USD and XBT/USD, got %v", pai)
+	}
+
+	{
+		cli.Add("LTC/USD")
+	}
+
+	{
+		upd := <-cli.Updates()
+		if upd.Pair != "LTC/USD" || !upd.Response.IsSnapshot {
+			t.Fatalf("expected snapshot for LTC/USD, got %s", upd.Pair)
+		}
+	}
+
+	// Updates of the removed pair must not be delivered, regardless of whether
+	// the server got the unsubscribe request already.
+
+	{
+		cli.Remove("XBT/USD")
+		srv.Update("XBT/USD", levels("1272.70000", "0.50000000"), nil)
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), nil)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if upd.Pair != "ETH/USD" {
+			t.Fatalf("expected update for ETH/USD, got %s", upd.Pair)
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if strings.Join(cli.Pairs(), ",") != "ETH/USD,LTC/USD" {
+		t.Fatalf("expected pairs ETH/USD and LTC/USD, got %v", cli.Pairs())
+	}
+
+	if cli.Book("XBT/USD") != nil {
+		t.Fatal("expected order book of removed pair to be gone")
+	}
+
+	var req []string
+	for _, x := range srv.Requests()[:2] {
+		req = append(req, x.Event+" "+strings.Join(x.Pair, ","))
+	}
+
+	if strings.Join(req, ";") != "subscribe ETH/USD,XBT/USD;subscribe LTC/USD" {
+		t.Fatalf("expected subscribe requests for all pairs, got %v", req)
+	}
+}
+
+// Test_Client_Run_Record ensures that every raw message gets passed to the
+// recording hook before it gets processed.
+func Test_Client_Run_Record(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var rec []string
+	var tim []time.Time
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Pai: []string{"ETH/USD"}, Rec: func(now time.Time, byt []byte) error {
+			rec = append(rec, string(byt))
+			tim = append(tim, now)
+			return nil
+		}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(rec) != 3 {
+		t.Fatalf("expected 3 recorded messages, got %d", len(rec))
+	}
+
+	if !strings.Contains(rec[0], "systemStatus") || !strings.Contains(rec[1], "subscriptionStatus") || !strings.Contains(rec[2], `"as":`) {
+		t.Fatalf("expected system status, subscription status and snapshot, got %v", rec)
+	}
+
+	if tim[0].IsZero() || tim[2].Before(tim[0]) {
+		t.Fatalf("expected ordered receive times, got %v", tim)
+	}
+}
+
+// Test_Client_Run_Reconnect ensures that the client reconnects once the server
+// drops the connection, and that the order book gets rebuilt from the snapshot
+// of the new subscription.
+func Test_Client_Run_Reconnect(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Min: time.Millisecond, Pai: []string{"ETH/USD"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+	}
+
+	{
+		srv.Drop()
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), levels("1271.81000", "3.25000000"))
+	}
+
+	{
+		upd := <-cli.Updates()
+		if !upd.Response.IsSnapshot {
+			t.Fatal("expected snapshot after reconnect")
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	met := cli.Metrics()
+	if met.ChecksumErrors != 0 || met.Reconnects != 1 {
+		t.Fatalf("expected 0 checksum errors and 1 reconnect, got %#v", met)
+	}
+
+	bid, _ := cli.Book("ETH/USD").BestBid()
+	if bid.Price.String() != "1271.81000" {
+		t.Fatalf("expected best bid 1271.81000, got %s", bid.Price)
+	}
+}
+
+// Test_Client_Run_Flapping ensures that the backoff keeps growing while the
+// server accepts connections and drops them right away, and that Config.Ret
+// applies to such connections as well.
+func Test_Client_Run_Flapping(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(10, "ETH/USD")
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Buf: 10, Dep: 10, Max: time.Second, Min: 10 * time.Millisecond, Pai: []string{"ETH/USD"}, Ret: 6})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	var tim []time.Time
+	for range cli.Updates() {
+		tim = append(tim, time.Now())
+		srv.Drop()
+	}
+
+	{
+		err := stp()
+		if err == nil {
+			t.Fatal("expected flapping connections to cause an error")
+		}
+	}
+
+	if len(tim) != 6 || cli.Metrics().Reconnects != 5 {
+		t.Fatalf("expected 6 snapshots and 5 reconnects, got %d and %d", len(tim), cli.Metrics().Reconnects)
+	}
+
+	// The jittered delay before reconnect attempt i is at least half of Min
+	// doubled i times.
+
+	for i := 1; i < len(tim); i++ {
+		low := 10 * time.Millisecond << (i - 1)
+		if tim[i].Sub(tim[i-1]) < low {
+			t.Fatalf("reconnect %d: expected delay of at least %s, got %s", i, low, tim[i].Sub(tim[i-1]))
+		}
+	}
+}
+
+// Test_Client_Run_Stale ensures that the client reconnects once the server
+// stops sending any messages, while heartbeats keep the feed alive.
+func Test_Client_Run_Stale(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = mock.New(mock.Config{Dep: 10, Hea: 10 * time.Millisecond})
+		srv.Seed("ETH/USD", testdataa(), testdatab())
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Min: time.Millisecond, Pai: []string{"ETH/USD"}, Sta: 50 * time.Millisecond})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		<-cli.Updates()
+		srv.Stall()
+		<-cli.Updates()
+	}
+
+	if !cli.Live("ETH/USD") {
+		t.Fatal("expected order book to be live after snapshot")
+	}
+
+	// Heartbeats keep the connection alive, while the order book is not live
+	// anymore, since it did not change recently.
+
+	{
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if cli.Live("ETH/USD") {
+		t.Fatal("expected order book not to be live without recent update")
+	}
+
+	if time.Since(cli.Heartbeat()) > 50*time.Millisecond {
+		t.Fatalf("expected recent heartbeat, got %s", cli.Heartbeat())
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	met := cli.Metrics()
+	if met.Reconnects != 1 || met.Stalls != 1 {
+		t.Fatalf("expected 1 reconnect and 1 stall, got %#v", met)
+	}
+}
+
+// Test_Client_Run_Slow ensures that a consumer not reading updates for longer
+// than the staleness interval does not cause a reconnect.
+func Test_Client_Run_Slow(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = mock.New(mock.Config{Dep: 10, Hea: 10 * time.Millisecond})
+		srv.Seed("ETH/USD", testdataa(), testdatab())
+		defer srv.Close()
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 10, Min: time.Millisecond, Pai: []string{"ETH/USD"}, Sta: 50 * time.Millisecond})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if !upd.Response.IsSnapshot {
+			t.Fatal("expected snapshot")
+		}
+	}
+
+	{
+		srv.Update("ETH/USD", levels("1272.70000", "0.50000000"), nil)
+	}
+
+	{
+		upd := <-cli.Updates()
+		if upd.Response.IsSnapshot {
+			t.Fatal("expected update of the initial subscription")
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	met := cli.Metrics()
+	if met.Reconnects != 0 || met.Stalls != 0 {
+		t.Fatalf("expected 0 reconnects and 0 stalls, got %#v", met)
+	}
+}
+
+func Test_Client_Run_Subscription(t *testing.T) {
+	var srv *mock.Server
+	{
+		srv = server(25)
+		defer srv.Close()
+	}
+
+	var erc chan error
+	{
+		erc = make(chan error, 1)
+	}
+
+	var cli *Client
+	{
+		cli = New(Config{API: srv.URL(), Dep: 25, Err: report(erc), Pai: []string{"ETH/FOO"}})
+	}
+
+	var stp func() error
+	{
+		stp = start(t, cli)
+	}
+
+	{
+		err := <-erc
+		if !strings.Contains(err.Error(), "Currency pair not supported") {
+			t.Fatalf("expected subscription error, got %v", err)
+		}
+	}
+
+	{
+		err := stp()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if cli.Book("ETH/FOO") != nil {
+		t.Fatal("expected rejected pair to be removed")
+	}
+
+	req := srv.Requests()[0]
+	if req.Event != "subscribe" || req.Subscription.Name != "book" || req.Subscription.Depth != 25 || req.Pair[0] != "ETH/FOO" {
+		t.Fatalf("expected book-25 subscribe request for ETH/FOO, got %#v", req)
+	}
+}
+
+// levels returns price levels from alternating price and volume strings.
+func levels(str ...string) []orderbook.Level {
+	var lev []orderbook.Level
+	for i := 0; i < len(str); i += 2 {
+		lev = append(lev, orderbook.Level{Price: orderbook.MustDecimal(str[i]), Volume: orderbook.MustDecimal(str[i+1])})
+	}
+
+	return lev
+}
+
+// report returns a Config.Err callback sending errors to the given channel,
+// dropping errors the channel has no capacity for.
+func report(erc chan error) func(error) {
+	return func(err error) {
+		select {
+		case erc <- err:
+		default:
+		}
+	}
+}
+
+// server starts a mock server for the given depth, where all given pairs get
+// seeded with the same order book state.
+func server(dep int, pai ...string) *mock.Server {
+	var srv *mock.Server
+	{
+		srv = mock.New(mock.Config{Dep: dep})
+	}
+
+	for _, x := range pai {
+		srv.Seed(x, testdataa(), testdatab())
+	}
+
+	return srv
+}
+
+// start runs the given client in the background. The returned function cancels
+// the client and returns the error Client.Run returned.
+func start(t *testing.T, cli *Client) func() error {
+	var ctx context.Context
+	var can context.CancelFunc
+	{
+		ctx, can = context.WithTimeout(context.Background(), 5*time.Second)
+		t.Cleanup(can)
+	}
+
+	var don chan error
+	{
+		don = make(chan error, 1)
+	}
+
+	go func() {
+		don <- cli.Run(ctx)
+	}()
+
+	return func() error {
+		can()
+		return <-don
+	}
+}
+
+// testdataa returns the ask side of an ETH/USD book-10 snapshot.
+func testdataa() []orderbook.Level {
+	return levels(
+		"1272.70000", "1.00000000",
+		"1272.71000", "2.39742752",
+		"1272.72000", "3.72191238",
+		"1272.74000", "5.50000000",
+		"1272.77000", "4.05813845",
+		"1272.78000", "25.58235843",
+		"1272.79000", "5.00000000",
+		"1272.80000", "2.99895264",
+		"1272.85000", "2.24585184",
+		"1272.86000", "1.00000000",
+	)
+}
+
+// testdatab returns the bid side of an ETH/USD book-10 snapshot.
+func testdatab() []orderbook.Level {
+	return levels(
+		"1271.80000", "4.03174746",
+		"1271.79000", "2.26656312",
+		"1271.60000", "0.65439574",
+		"1271.59000", "26.08235843",
+		"1271.58000", "5.50000000",
+		"1271.57000", "3.87724786",
+		"1271.56000", "3.89057615",
+		"1271.54000", "4.74487479",
+		"1271.49000", "58.98572653",
+		"1271.40000", "1.93804942",
+	)
+}
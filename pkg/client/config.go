@@ -0,0 +1,78 @@
+package client
+
+import (
+	"time"
+
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+type Config struct {
+	// API is the websocket API v1 endpoint to connect to, e.g.
+	// wss://ws.kraken.com.
+	API string
+	// Buf is the buffer size of the updates channel. Zero means unbuffered.
+	Buf int
+	// Dep is the order book depth to subscribe to, which must be one of
+	// orderbook.Depths.
+	Dep int
+	// Err is called for errors that do not end the stream, e.g. malformed
+	// messages or Kraken error events. Err may be nil.
+	Err func(error)
+	// Max is the maximum delay between two connection attempts. The delay only
+	// starts over from Min once a connection stayed up for Max. Defaults to
+	// one minute.
+	Max time.Duration
+	// Min is the delay before the first reconnection attempt, which doubles
+	// with every consecutive failure up to Max. Defaults to one second.
+	Min time.Duration
+	// Pai are the asset pairs to subscribe to initially, e.g. ETH/USD. Pairs
+	// can be added and removed at runtime via Client.Add and Client.Remove.
+	Pai []string
+	// Rec is called with every raw message received, together with its local
+	// receive time, before the message gets processed. Errors returned by Rec
+	// are reported via Err. Rec may be nil. See capture.Recorder.Record.
+	Rec func(time.Time, []byte) error
+	// Ret is the number of consecutive failed connection attempts after which
+	// Client.Run gives up. A connection that drops before staying up for Max
+	// counts as failed attempt. Zero means to retry forever.
+	Ret int
+	// Sta is the interval after which the feed is considered stale. If neither
+	// a heartbeat got received nor any order book changed within Sta, or any
+	// order book did not receive a snapshot within Sta after getting reset, the
+	// Client reconnects. Time spent waiting for the consumer of Client.Updates
+	// does not count. Defaults to ten seconds.
+	Sta time.Duration
+}
+
+func (c Config) Verify() {
+	if c.API == "" {
+		panic("Config.API must not be empty")
+	}
+	if c.Buf < 0 {
+		panic("Config.Buf must not be negative")
+	}
+	if c.Max < 0 {
+		panic("Config.Max must not be negative")
+	}
+	if c.Min < 0 {
+		panic("Config.Min must not be negative")
+	}
+	if c.Max != 0 && c.Min > c.Max {
+		panic("Config.Min must not be greater than Config.Max")
+	}
+	for _, x := range c.Pai {
+		if x == "" {
+			panic("Config.Pai must not contain empty pairs")
+		}
+	}
+	if c.Ret < 0 {
+		panic("Config.Ret must not be negative")
+	}
+	if c.Sta < 0 {
+		panic("Config.Sta must not be negative")
+	}
+
+	{
+		orderbook.Config{Dep: c.Dep}.Verify()
+	}
+}
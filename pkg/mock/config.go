@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+type Config struct {
+	// Dep is the only order book depth the Server accepts subscriptions for,
+	// which must be one of orderbook.Depths. Book messages are generated for
+	// this depth.
+	Dep int
+	// Hea is the interval heartbeats are sent with to every connection. Zero
+	// disables heartbeats.
+	Hea time.Duration
+}
+
+func (c Config) Verify() {
+	if c.Hea < 0 {
+		panic("Config.Hea must not be negative")
+	}
+
+	{
+		orderbook.Config{Dep: c.Dep}.Verify()
+	}
+}
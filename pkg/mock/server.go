@@ -0,0 +1,517 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Request is a subscribe or unsubscribe request received by the Server.
+type Request struct {
+	Event        string                 `json:"event"`
+	Pair         []string               `json:"pair"`
+	ReqID        int64                  `json:"reqid,omitempty"`
+	Subscription orderbook.Subscription `json:"subscription"`
+}
+
+// Server is a local fake of the Kraken websocket API v1, speaking the
+// subscribe protocol of the book channel. The Server maintains an order book
+// per pair, which gets seeded via Server.Seed and changed via Server.Update.
+// Every subscription receives a snapshot of the current order book state,
+// followed by all updates with correct checksums, unless failures get injected
+// via Server.Corrupt, Server.Cross, Server.Drop, Server.Error, Server.Lose or
+// Server.Stall.
+type Server struct {
+	bok map[string]*book
+	cid int64
+	con Config
+	mut sync.Mutex
+	req []Request
+	soc map[*conn]struct{}
+	srv *httptest.Server
+}
+
+func New(con Config) *Server {
+	{
+		con.Verify()
+	}
+
+	var srv *Server
+	{
+		srv = &Server{
+			bok: map[string]*book{},
+			cid: 100,
+			con: con,
+			soc: map[*conn]struct{}{},
+		}
+	}
+
+	{
+		srv.srv = httptest.NewServer(http.HandlerFunc(srv.handle))
+	}
+
+	return srv
+}
+
+// Close drops all connections and shuts the Server down.
+func (s *Server) Close() {
+	{
+		s.Drop()
+	}
+
+	{
+		s.srv.Close()
+	}
+}
+
+// Corrupt causes the next update of the given pair to carry a wrong checksum.
+// The order book state of the Server is not affected, so that subscribing
+// again yields a correct snapshot.
+func (s *Server) Corrupt(pai string) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.book(pai).cor = true
+}
+
+// Cross causes the next snapshot of the given pair to be crossed, that is, to
+// contain an additional bid at the price of the best ask. Calling Cross n times
+// crosses the next n snapshots. The order book state of the Server is not
+// affected, so that subscribing again yields a correct snapshot.
+func (s *Server) Cross(pai string) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.book(pai).crs++
+}
+
+// Drop closes all current connections without a close handshake, as if the
+// network failed.
+func (s *Server) Drop() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for x := range s.soc {
+		x.soc.Close()
+	}
+}
+
+// Error sends an error event with the given message to all current
+// connections.
+func (s *Server) Error(msg string) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for x := range s.soc {
+		x.json(map[string]any{"event": "error", "errorMessage": msg})
+	}
+}
+
+// Lose causes all current subscriptions of the given pair to get lost right
+// after the next update of the pair got sent, as if Kraken dropped them
+// silently. Unsubscribing from the pair afterwards fails with "Subscription
+// Not Found", while subscribing again succeeds.
+func (s *Server) Lose(pai string) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.book(pai).los = true
+}
+
+// Requests returns all requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return append([]Request(nil), s.req...)
+}
+
+// Seed replaces the order book state of the given pair, which makes the pair
+// available for subscriptions. Seed does not notify current subscribers, so it
+// is meant to be called before subscribing.
+func (s *Server) Seed(pai string, ask []orderbook.Level, bid []orderbook.Level) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var obk *orderbook.Orderbook
+	{
+		obk = orderbook.New(orderbook.Config{Dep: s.con.Dep})
+	}
+
+	err := obk.Snapshot(orderbook.Response{Asks: objects(ask), Bids: objects(bid), IsSnapshot: true})
+	if err != nil {
+		panic(err)
+	}
+
+	{
+		obk.Truncate()
+	}
+
+	{
+		s.bok[pai] = &book{obk: obk}
+	}
+}
+
+// Stall stops sending any messages, including heartbeats, to all current
+// connections, while keeping them open. New connections are not affected.
+func (s *Server) Stall() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for x := range s.soc {
+		x.wri.Lock()
+		x.stl = true
+		x.wri.Unlock()
+	}
+}
+
+// URL returns the websocket URL of the Server, e.g. ws://127.0.0.1:1234.
+func (s *Server) URL() string {
+	return "ws" + strings.TrimPrefix(s.srv.URL, "http")
+}
+
+// Update applies the given price levels to the order book of the given pair
+// and sends them to all subscribers, together with the resulting checksum.
+// Levels with zero volume remove the respective price level.
+func (s *Server) Update(pai string, ask []orderbook.Level, bid []orderbook.Level) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var bok *book
+	{
+		bok = s.book(pai)
+	}
+
+	err := bok.obk.Update(orderbook.Response{Asks: objects(ask), Bids: objects(bid)})
+	if err != nil {
+		panic(err)
+	}
+
+	{
+		bok.obk.Truncate()
+	}
+
+	var sum string
+	{
+		sum = bok.obk.Checksum()
+	}
+
+	if bok.cor {
+		num, _ := strconv.ParseUint(sum, 10, 32)
+		sum = strconv.FormatUint(num^1, 10)
+		bok.cor = false
+	}
+
+	// Kraken sends updates touching both sides as two separate payload
+	// objects, where the checksum is part of the last one.
+
+	var pay []map[string]any
+	if len(ask) != 0 {
+		pay = append(pay, map[string]any{"a": levels(ask)})
+	}
+	if len(bid) != 0 {
+		pay = append(pay, map[string]any{"b": levels(bid)})
+	}
+
+	if len(pay) == 0 {
+		return
+	}
+
+	{
+		pay[len(pay)-1]["c"] = sum
+	}
+
+	for x := range s.soc {
+		cid, ok := x.sub[pai]
+		if !ok {
+			continue
+		}
+
+		var frm []any
+		{
+			frm = append(frm, cid)
+			for _, y := range pay {
+				frm = append(frm, y)
+			}
+			frm = append(frm, s.name(), pai)
+		}
+
+		x.json(frm)
+	}
+
+	if bok.los {
+		for x := range s.soc {
+			delete(x.sub, pai)
+		}
+
+		bok.los = false
+	}
+}
+
+// book returns the order book of the given pair and panics if the pair did not
+// get seeded.
+func (s *Server) book(pai string) *book {
+	if s.bok[pai] == nil {
+		panic(fmt.Sprintf("pair %s must be seeded", pai))
+	}
+
+	return s.bok[pai]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	var con *conn
+	{
+		con = &conn{sub: map[string]int64{}}
+	}
+
+	{
+		con.soc, err = (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer con.soc.Close()
+	}
+
+	{
+		s.mut.Lock()
+		s.soc[con] = struct{}{}
+		s.mut.Unlock()
+	}
+
+	defer func() {
+		s.mut.Lock()
+		delete(s.soc, con)
+		s.mut.Unlock()
+	}()
+
+	{
+		con.json(map[string]any{"connectionID": 1, "event": "systemStatus", "status": "online", "version": "1.9.0"})
+	}
+
+	var don chan struct{}
+	{
+		don = make(chan struct{})
+		defer close(don)
+	}
+
+	if s.con.Hea != 0 {
+		go con.heartbeat(s.con.Hea, don)
+	}
+
+	for {
+		var req Request
+		{
+			err = con.soc.ReadJSON(&req)
+			if err != nil {
+				return
+			}
+		}
+
+		{
+			s.request(con, req)
+		}
+	}
+}
+
+// name returns the channel name of the book channel, e.g. book-10.
+func (s *Server) name() string {
+	return fmt.Sprintf("book-%d", s.con.Dep)
+}
+
+// request answers the given request the way Kraken does, with one
+// subscription status per requested pair.
+func (s *Server) request(con *conn, req Request) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	{
+		s.req = append(s.req, req)
+	}
+
+	if req.Event != "subscribe" && req.Event != "unsubscribe" {
+		con.json(map[string]any{"event": "error", "errorMessage": "Unsupported event", "reqid": req.ReqID})
+		return
+	}
+
+	for _, x := range req.Pair {
+		sts := status{
+			Event: "subscriptionStatus",
+			SubscriptionStatus: orderbook.SubscriptionStatus{
+				ChannelName:  s.name(),
+				Pair:         x,
+				ReqID:        req.ReqID,
+				Subscription: req.Subscription,
+			},
+		}
+
+		var msg string
+		{
+			msg = s.verify(con, req, x)
+		}
+
+		if msg != "" {
+			sts.ErrorMessage = msg
+			sts.Status = "error"
+			con.json(sts)
+			continue
+		}
+
+		if req.Event == "unsubscribe" {
+			sts.ChannelID = con.sub[x]
+			sts.Status = "unsubscribed"
+			delete(con.sub, x)
+			con.json(sts)
+			continue
+		}
+
+		{
+			s.cid++
+			con.sub[x] = s.cid
+		}
+
+		{
+			sts.ChannelID = s.cid
+			sts.Status = "subscribed"
+			con.json(sts)
+		}
+
+		var ask []orderbook.Level
+		var bid []orderbook.Level
+		{
+			ask = s.bok[x].obk.Asks(s.con.Dep)
+			bid = s.bok[x].obk.Bids(s.con.Dep)
+		}
+
+		if s.bok[x].crs != 0 && len(ask) != 0 {
+			bid = append([]orderbook.Level{ask[0]}, bid...)
+			s.bok[x].crs--
+		}
+
+		{
+			con.json([]any{
+				s.cid,
+				map[string]any{
+					"as": levels(ask),
+					"bs": levels(bid),
+				},
+				s.name(),
+				x,
+			})
+		}
+	}
+}
+
+// verify returns the error message Kraken would respond with to the given
+// request for the given pair, or an empty string if the request is valid.
+func (s *Server) verify(con *conn, req Request, pai string) string {
+	if req.Subscription.Name != "book" {
+		return "Subscription name invalid"
+	}
+
+	if req.Subscription.Depth != s.con.Dep {
+		return "Subscription depth not supported"
+	}
+
+	if s.bok[pai] == nil {
+		return fmt.Sprintf("Currency pair not supported %s", pai)
+	}
+
+	_, ok := con.sub[pai]
+	if req.Event == "subscribe" && ok {
+		return "Already subscribed"
+	}
+
+	if req.Event == "unsubscribe" && !ok {
+		return "Subscription Not Found"
+	}
+
+	return ""
+}
+
+// book is the order book state of a single pair.
+type book struct {
+	cor bool
+	crs int
+	los bool
+	obk *orderbook.Orderbook
+}
+
+// conn is a single client connection.
+type conn struct {
+	soc *websocket.Conn
+	stl bool
+	sub map[string]int64
+	wri sync.Mutex
+}
+
+func (c *conn) heartbeat(dur time.Duration, don <-chan struct{}) {
+	var tic *time.Ticker
+	{
+		tic = time.NewTicker(dur)
+		defer tic.Stop()
+	}
+
+	for {
+		select {
+		case <-tic.C:
+		case <-don:
+			return
+		}
+
+		c.json(map[string]any{"event": "heartbeat"})
+	}
+}
+
+// json writes the given message as JSON, unless the connection is stalled.
+// Write errors are ignored, since the read loop detects broken connections.
+func (c *conn) json(msg any) {
+	c.wri.Lock()
+	defer c.wri.Unlock()
+
+	if c.stl {
+		return
+	}
+
+	c.soc.WriteJSON(msg)
+}
+
+// status is a subscription status event as sent by Kraken.
+type status struct {
+	Event string `json:"event"`
+	orderbook.SubscriptionStatus
+}
+
+// levels formats the given price levels the way Kraken does, as string triples
+// of price, volume and timestamp.
+func levels(lev []orderbook.Level) [][]string {
+	var tim string
+	{
+		now := time.Now()
+		tim = fmt.Sprintf("%d.%06d", now.Unix(), now.Nanosecond()/1000)
+	}
+
+	var str [][]string
+	for _, x := range lev {
+		str = append(str, []string{x.Price.String(), x.Volume.String(), tim})
+	}
+
+	return str
+}
+
+func objects(lev []orderbook.Level) []orderbook.Object {
+	var obj []orderbook.Object
+	for _, x := range lev {
+		obj = append(obj, orderbook.Object{Price: x.Price, Volume: x.Volume})
+	}
+
+	return obj
+}
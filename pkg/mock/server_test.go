@@ -0,0 +1,237 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Test_Server_Update aims to cover the whole protocol of the Server, from
+// subscribing to receiving updates that pass checksum verification of an
+// orderbook.Orderbook, including injected checksum failures.
+func Test_Server_Update(t *testing.T) {
+	var srv *Server
+	{
+		srv = New(Config{Dep: 10})
+		defer srv.Close()
+	}
+
+	{
+		srv.Seed("ETH/USD", testdataa(), testdatab())
+	}
+
+	var con *websocket.Conn
+	{
+		con = dial(t, srv)
+		defer con.Close()
+	}
+
+	var obk *orderbook.Orderbook
+	{
+		obk = orderbook.New(orderbook.Config{Dep: 10})
+	}
+
+	{
+		subscribe(t, con, "subscribe", "ETH/USD")
+	}
+
+	{
+		evn := event(t, con)
+		if evn.Event != "systemStatus" {
+			t.Fatalf("expected systemStatus event, got %s", evn.Event)
+		}
+	}
+
+	{
+		evn := event(t, con)
+		if evn.Event != "subscriptionStatus" {
+			t.Fatalf("expected subscriptionStatus event, got %s", evn.Event)
+		}
+	}
+
+	{
+		err := obk.Middleware(frame(t, con))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The first update touches both sides, the second one removes a price
+	// level, and the third one carries an injected checksum failure.
+
+	{
+		srv.Update("ETH/USD", level("100.50000", "1.00000000"), level("99.50000", "2.00000000"))
+		srv.Update("ETH/USD", level("101.00000", "0.00000000"), nil)
+		srv.Corrupt("ETH/USD")
+		srv.Update("ETH/USD", nil, level("98.00000", "3.00000000"))
+	}
+
+	for i := 0; i < 2; i++ {
+		err := obk.Middleware(frame(t, con))
+		if err != nil {
+			t.Fatalf("update %d: %s", i, err)
+		}
+	}
+
+	{
+		var che *orderbook.ChecksumError
+		if !errors.As(obk.Middleware(frame(t, con)), &che) {
+			t.Fatal("expected corrupted update to fail checksum verification")
+		}
+	}
+
+	ask, _ := obk.BestAsk()
+	if ask.Price.String() != "100.00000" {
+		t.Fatalf("expected best ask 100.00000, got %s", ask.Price)
+	}
+}
+
+func Test_Server_Request(t *testing.T) {
+	var srv *Server
+	{
+		srv = New(Config{Dep: 10})
+		defer srv.Close()
+	}
+
+	{
+		srv.Seed("ETH/USD", testdataa(), testdatab())
+	}
+
+	var con *websocket.Conn
+	{
+		con = dial(t, srv)
+		defer con.Close()
+	}
+
+	{
+		event(t, con)
+	}
+
+	testCases := []struct {
+		evn string
+		pai string
+		sts string
+		msg string
+	}{
+		{evn: "subscribe", pai: "ETH/FOO", sts: "error", msg: "Currency pair not supported ETH/FOO"},
+		{evn: "unsubscribe", pai: "ETH/USD", sts: "error", msg: "Subscription Not Found"},
+		{evn: "subscribe", pai: "ETH/USD", sts: "subscribed"},
+		{evn: "subscribe", pai: "ETH/USD", sts: "error", msg: "Already subscribed"},
+		{evn: "unsubscribe", pai: "ETH/USD", sts: "unsubscribed"},
+	}
+
+	for i, tc := range testCases {
+		{
+			subscribe(t, con, tc.evn, tc.pai)
+		}
+
+		var sts orderbook.SubscriptionStatus
+		{
+			err := json.Unmarshal(event(t, con).Raw, &sts)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if sts.Status != tc.sts || sts.ErrorMessage != tc.msg || sts.Pair != tc.pai {
+			t.Fatalf("test case %d: expected status %q with message %q, got %#v", i, tc.sts, tc.msg, sts)
+		}
+
+		if sts.Status == "subscribed" {
+			frame(t, con)
+		}
+	}
+
+	if len(srv.Requests()) != len(testCases) {
+		t.Fatalf("expected %d requests, got %d", len(testCases), len(srv.Requests()))
+	}
+}
+
+func dial(t *testing.T, srv *Server) *websocket.Conn {
+	con, _, err := websocket.DefaultDialer.Dial(srv.URL(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return con
+}
+
+// event reads the next message and requires it to be an event.
+func event(t *testing.T, con *websocket.Conn) orderbook.Event {
+	msg := message(t, con)
+	if msg.Event == nil {
+		t.Fatal("expected event")
+	}
+
+	return *msg.Event
+}
+
+// frame reads the next message and requires it to be a book frame.
+func frame(t *testing.T, con *websocket.Conn) orderbook.Response {
+	msg := message(t, con)
+	if msg.Frame == nil || !msg.Frame.Book() {
+		t.Fatal("expected book frame")
+	}
+
+	rsp, err := msg.Frame.Response()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rsp
+}
+
+func level(pri string, vol string) []orderbook.Level {
+	return []orderbook.Level{{Price: orderbook.MustDecimal(pri), Volume: orderbook.MustDecimal(vol)}}
+}
+
+func message(t *testing.T, con *websocket.Conn) orderbook.Message {
+	_, byt, err := con.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := orderbook.Decode(byt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return msg
+}
+
+func subscribe(t *testing.T, con *websocket.Conn, evn string, pai string) {
+	err := con.WriteJSON(Request{
+		Event:        evn,
+		Pair:         []string{pai},
+		Subscription: orderbook.Subscription{Name: "book", Depth: 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testdataa returns twelve ask price levels, two more than the depth used in
+// tests, starting at 100.00000.
+func testdataa() []orderbook.Level {
+	var lev []orderbook.Level
+	for i := 0; i < 12; i++ {
+		lev = append(lev, level(fmt.Sprintf("%d.00000", 100+i), fmt.Sprintf("%d.50000000", i+1))...)
+	}
+
+	return lev
+}
+
+// testdatab returns twelve bid price levels, two more than the depth used in
+// tests, starting at 99.00000.
+func testdatab() []orderbook.Level {
+	var lev []orderbook.Level
+	for i := 0; i < 12; i++ {
+		lev = append(lev, level(fmt.Sprintf("%d.00000", 99-i), fmt.Sprintf("%d.25000000", i+1))...)
+	}
+
+	return lev
+}
@@ -0,0 +1,24 @@
+package orderbook
+
+import "fmt"
+
+// Depths are the order book depths supported by the Kraken book channel.
+var Depths = []int{10, 25, 100, 500, 1000}
+
+type Config struct {
+	// Dep is the order book depth subscribed to, which must be one of Depths.
+	// The depth governs the number of price levels kept on either side of the
+	// order book. Note that the checksum is always calculated over the top ten
+	// price levels, regardless of the subscribed depth.
+	Dep int
+}
+
+func (c Config) Verify() {
+	for _, x := range Depths {
+		if c.Dep == x {
+			return
+		}
+	}
+
+	panic(fmt.Sprintf("Config.Dep must be one of %v, got %d", Depths, c.Dep))
+}
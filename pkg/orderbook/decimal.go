@@ -0,0 +1,333 @@
+package orderbook
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point decimal number, representing the value
+// man * 10^-sca. Kraken sends prices and volumes as strings formatted with the
+// pair's price and lot decimals respectively, e.g. "1289.60000" and
+// "0.01551181" for ETH/USD. A Decimal parsed from such a string keeps its number
+// of decimals, so that formatting it again yields the exact same string, which
+// is required for the checksum calculation. Arithmetic on Decimals never
+// rounds, unless explicitly asked for via Decimal.Quo or Decimal.Rescale.
+//
+// The zero value of Decimal is zero with no decimals. Decimals are immutable
+// and safe to copy. Note that Decimals must be compared using Decimal.Cmp,
+// since the same value may be represented with different numbers of decimals.
+type Decimal struct {
+	man *big.Int
+	sca int32
+}
+
+// NewDecimal returns the Decimal man * 10^-sca.
+func NewDecimal(man int64, sca int32) Decimal {
+	if sca < 0 {
+		panic(fmt.Sprintf("scale must not be negative, got %d", sca))
+	}
+
+	return Decimal{man: big.NewInt(man), sca: sca}
+}
+
+// MustDecimal is like ParseDecimal but panics if the given string cannot be
+// parsed.
+func MustDecimal(str string) Decimal {
+	dec, err := ParseDecimal(str)
+	if err != nil {
+		panic(err)
+	}
+
+	return dec
+}
+
+// ParseDecimal parses a plain decimal string like "-1289.60000". The number of
+// digits after the decimal point defines the scale of the returned Decimal.
+// Exponents, signs other than a leading minus and empty digit groups are
+// rejected.
+func ParseDecimal(str string) (Decimal, error) {
+	var num string
+	var neg bool
+	{
+		num = str
+	}
+
+	if strings.HasPrefix(num, "-") {
+		num = num[1:]
+		neg = true
+	}
+
+	var whl string
+	var fra string
+	{
+		whl, fra, _ = strings.Cut(num, ".")
+	}
+
+	if whl == "" || (strings.Contains(num, ".") && fra == "") {
+		return Decimal{}, fmt.Errorf("decimal %q must have digits on both sides of the decimal point", str)
+	}
+
+	for _, x := range whl + fra {
+		if x < '0' || x > '9' {
+			return Decimal{}, fmt.Errorf("decimal %q must only contain digits and a single decimal point", str)
+		}
+	}
+
+	var man *big.Int
+	{
+		man, _ = new(big.Int).SetString(whl+fra, 10)
+	}
+
+	if neg {
+		man.Neg(man)
+	}
+
+	return Decimal{man: man, sca: int32(len(fra))}, nil
+}
+
+// Add returns d + e with the larger scale of both.
+func (d Decimal) Add(e Decimal) Decimal {
+	x, y, s := align(d, e)
+	return Decimal{man: new(big.Int).Add(x, y), sca: s}
+}
+
+// Cmp compares d and e and returns -1 if d < e, 0 if d == e and +1 if d > e.
+func (d Decimal) Cmp(e Decimal) int {
+	if d.sca == e.sca {
+		return d.mantissa().Cmp(e.mantissa())
+	}
+
+	x, y, _ := align(d, e)
+	return x.Cmp(y)
+}
+
+// Float64 returns the nearest float64 value of d. Float64 is meant for
+// presentation only and must not be used for any further calculation.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.mantissa(), pow10(d.sca)).Float64()
+	return f
+}
+
+// IsZero returns whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// MarshalJSON encodes d as a JSON number with its exact decimals.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// Mul returns d * e with the sum of both scales, which is exact.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{man: new(big.Int).Mul(d.mantissa(), e.mantissa()), sca: d.sca + e.sca}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{man: new(big.Int).Neg(d.mantissa()), sca: d.sca}
+}
+
+// Quo returns d / e rounded half away from zero to sca decimals. Quo panics
+// if e is zero or sca is negative.
+func (d Decimal) Quo(e Decimal, sca int32) Decimal {
+	if e.IsZero() {
+		panic("division by zero")
+	}
+
+	if sca < 0 {
+		panic(fmt.Sprintf("scale must not be negative, got %d", sca))
+	}
+
+	// With d = x * 10^-a and e = y * 10^-b, the quotient scaled to sca
+	// decimals is x * 10^(sca+b-a) / y. Scaling by one additional decimal
+	// allows us to round half away from zero afterwards.
+
+	var num *big.Int
+	var den *big.Int
+	{
+		num = new(big.Int).Set(d.mantissa())
+		den = new(big.Int).Set(e.mantissa())
+	}
+
+	exp := int64(sca) + int64(e.sca) - int64(d.sca) + 1
+	if exp >= 0 {
+		num.Mul(num, pow10(int32(exp)))
+	} else {
+		den.Mul(den, pow10(int32(-exp)))
+	}
+
+	return Decimal{man: round(num.Quo(num, den)), sca: sca}
+}
+
+// Rescale returns d with exactly sca decimals. Increasing the scale is exact,
+// while decreasing the scale rounds half away from zero.
+func (d Decimal) Rescale(sca int32) Decimal {
+	if sca < 0 {
+		panic(fmt.Sprintf("scale must not be negative, got %d", sca))
+	}
+
+	if sca >= d.sca {
+		return Decimal{man: new(big.Int).Mul(d.mantissa(), pow10(sca-d.sca)), sca: sca}
+	}
+
+	var man *big.Int
+	{
+		man = new(big.Int).Quo(d.mantissa(), pow10(d.sca-sca-1))
+	}
+
+	return Decimal{man: round(man), sca: sca}
+}
+
+// Scale returns the number of decimals of d.
+func (d Decimal) Scale() int32 {
+	return d.sca
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.mantissa().Sign()
+}
+
+// String formats d with exactly Decimal.Scale decimals.
+func (d Decimal) String() string {
+	var str string
+	{
+		str = new(big.Int).Abs(d.mantissa()).String()
+	}
+
+	if d.sca > 0 {
+		if len(str) <= int(d.sca) {
+			str = strings.Repeat("0", int(d.sca)-len(str)+1) + str
+		}
+
+		str = str[:len(str)-int(d.sca)] + "." + str[len(str)-int(d.sca):]
+	}
+
+	if d.Sign() < 0 {
+		str = "-" + str
+	}
+
+	return str
+}
+
+// Sub returns d - e with the larger scale of both.
+func (d Decimal) Sub(e Decimal) Decimal {
+	x, y, s := align(d, e)
+	return Decimal{man: new(big.Int).Sub(x, y), sca: s}
+}
+
+// UnmarshalJSON decodes d from a JSON number or a JSON string containing a
+// plain decimal number. Other than ParseDecimal, UnmarshalJSON accepts
+// exponents as allowed by the JSON number grammar, e.g. 1.5e-05.
+func (d *Decimal) UnmarshalJSON(byt []byte) error {
+	var str string
+	{
+		str = string(bytes.Trim(byt, `"`))
+	}
+
+	var num string
+	var exp string
+	{
+		num, exp, _ = strings.Cut(strings.ToLower(str), "e")
+	}
+
+	dec, err := ParseDecimal(num)
+	if err != nil {
+		return err
+	}
+
+	if exp != "" {
+		var shi int64
+		{
+			shi, err = strconv.ParseInt(strings.TrimPrefix(exp, "+"), 10, 32)
+			if err != nil || shi < -64 || shi > 64 {
+				return fmt.Errorf("decimal %q must have an integer exponent between -64 and 64", str)
+			}
+		}
+
+		// A positive exponent moves the decimal point to the right, which
+		// reduces the scale. Once the scale reaches zero, the mantissa itself
+		// has to be scaled up.
+
+		sca := int64(dec.sca) - shi
+		if sca < 0 {
+			dec = Decimal{man: new(big.Int).Mul(dec.mantissa(), pow10(int32(-sca))), sca: 0}
+		} else {
+			dec = Decimal{man: dec.mantissa(), sca: int32(sca)}
+		}
+	}
+
+	*d = dec
+
+	return nil
+}
+
+// digits appends the absolute mantissa of d without leading zeros to the given
+// buffer. This is the string representation Kraken specifies for prices and
+// volumes as part of the checksum calculation, that is, the formatted value
+// with the decimal point and all leading zeros removed. Mantissas fitting into
+// an int64, which is the case for all prices and volumes Kraken sends, get
+// formatted without allocating.
+func (d Decimal) digits(buf []byte) []byte {
+	if d.Sign() == 0 {
+		return buf
+	}
+
+	if d.man.IsInt64() && d.man.Int64() > math.MinInt64 {
+		man := d.man.Int64()
+		if man < 0 {
+			man = -man
+		}
+
+		return strconv.AppendInt(buf, man, 10)
+	}
+
+	return new(big.Int).Abs(d.man).Append(buf, 10)
+}
+
+func (d Decimal) mantissa() *big.Int {
+	if d.man == nil {
+		return new(big.Int)
+	}
+
+	return d.man
+}
+
+// align returns the mantissas of d and e scaled to the larger scale of both,
+// together with that scale.
+func align(d Decimal, e Decimal) (*big.Int, *big.Int, int32) {
+	if d.sca > e.sca {
+		return d.mantissa(), new(big.Int).Mul(e.mantissa(), pow10(d.sca-e.sca)), d.sca
+	}
+
+	if d.sca < e.sca {
+		return new(big.Int).Mul(d.mantissa(), pow10(e.sca-d.sca)), e.mantissa(), e.sca
+	}
+
+	return d.mantissa(), e.mantissa(), d.sca
+}
+
+func pow10(exp int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// round takes a mantissa carrying one additional decimal and rounds it half
+// away from zero, removing the additional decimal.
+func round(man *big.Int) *big.Int {
+	var quo *big.Int
+	var rem *big.Int
+	{
+		quo, rem = new(big.Int).QuoRem(man, big.NewInt(10), new(big.Int))
+	}
+
+	if rem.CmpAbs(big.NewInt(5)) >= 0 {
+		quo.Add(quo, big.NewInt(int64(man.Sign())))
+	}
+
+	return quo
+}
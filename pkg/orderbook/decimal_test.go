@@ -0,0 +1,160 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_Decimal_Parse(t *testing.T) {
+	testCases := []struct {
+		str string
+		err bool
+	}{
+		{str: "1289.60000"},
+		{str: "0.01551181"},
+		{str: "0"},
+		{str: "-0.50"},
+		{str: "1677"},
+		{str: "", err: true},
+		{str: ".5", err: true},
+		{str: "5.", err: true},
+		{str: "1.2.3", err: true},
+		{str: "1e-5", err: true},
+		{str: "+1.0", err: true},
+		{str: "abc", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.str, func(t *testing.T) {
+			dec, err := ParseDecimal(tc.str)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected %q to fail parsing", tc.str)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dec.String() != tc.str {
+				t.Fatalf("expected %q, got %q", tc.str, dec.String())
+			}
+		})
+	}
+}
+
+func Test_Decimal_Arithmetic(t *testing.T) {
+	testCases := []struct {
+		res Decimal
+		exp string
+	}{
+		{res: MustDecimal("0.1").Add(MustDecimal("0.2")), exp: "0.3"},
+		{res: MustDecimal("1289.60000").Sub(MustDecimal("1289.59")), exp: "0.01000"},
+		{res: MustDecimal("1289.60000").Mul(MustDecimal("0.01551181")), exp: "20.0040301760000"},
+		{res: MustDecimal("1").Quo(MustDecimal("3"), 8), exp: "0.33333333"},
+		{res: MustDecimal("2").Quo(MustDecimal("3"), 8), exp: "0.66666667"},
+		{res: MustDecimal("-2").Quo(MustDecimal("3"), 2), exp: "-0.67"},
+		{res: MustDecimal("1.25").Rescale(1), exp: "1.3"},
+		{res: MustDecimal("-1.25").Rescale(1), exp: "-1.3"},
+		{res: MustDecimal("1.24").Rescale(1), exp: "1.2"},
+		{res: MustDecimal("1.2").Rescale(4), exp: "1.2000"},
+		{res: MustDecimal("0.5").Neg(), exp: "-0.5"},
+		{res: Decimal{}, exp: "0"},
+	}
+
+	for i, tc := range testCases {
+		if tc.res.String() != tc.exp {
+			t.Fatalf("test case %d: expected %q, got %q", i, tc.exp, tc.res.String())
+		}
+	}
+}
+
+func Test_Decimal_Quo_Scale(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected negative scale to panic")
+		}
+	}()
+
+	MustDecimal("1").Quo(MustDecimal("3"), -1)
+}
+
+func Test_Decimal_Cmp(t *testing.T) {
+	testCases := []struct {
+		a   string
+		b   string
+		cmp int
+	}{
+		{a: "1289.60000", b: "1289.6", cmp: 0},
+		{a: "1289.60000", b: "1289.60001", cmp: -1},
+		{a: "1289.7", b: "1289.60001", cmp: +1},
+		{a: "-1", b: "0.00000001", cmp: -1},
+	}
+
+	for _, tc := range testCases {
+		if MustDecimal(tc.a).Cmp(MustDecimal(tc.b)) != tc.cmp {
+			t.Fatalf("expected %s compared to %s to be %d", tc.a, tc.b, tc.cmp)
+		}
+	}
+}
+
+func Test_Decimal_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		byt string
+		exp string
+		err bool
+	}{
+		{byt: `1272.7`, exp: "1272.7"},
+		{byt: `"0.01551181"`, exp: "0.01551181"},
+		{byt: `0`, exp: "0"},
+		{byt: `1.5e-05`, exp: "0.000015"},
+		{byt: `1.5E+3`, exp: "1500"},
+		{byt: `12.345e1`, exp: "123.45"},
+		{byt: `1e999`, err: true},
+		{byt: `true`, err: true},
+	}
+
+	for _, tc := range testCases {
+		var dec Decimal
+		err := json.Unmarshal([]byte(tc.byt), &dec)
+		if tc.err {
+			if err == nil {
+				t.Fatalf("expected %s to fail decoding", tc.byt)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if dec.String() != tc.exp {
+			t.Fatalf("expected %s, got %s", tc.exp, dec)
+		}
+	}
+}
+
+func Test_Decimal_digits(t *testing.T) {
+	testCases := []struct {
+		str string
+		exp string
+	}{
+		{str: "1289.60000", exp: "128960000"},
+		{str: "0.01551181", exp: "1551181"},
+		{str: "0.00000000", exp: ""},
+		{str: "0.00000001", exp: "1"},
+		{str: "-1289.60000", exp: "128960000"},
+		{str: "-9223372036854775808", exp: "9223372036854775808"},
+		{str: "123456789012345678901234.5", exp: "1234567890123456789012345"},
+	}
+
+	for _, tc := range testCases {
+		if string(MustDecimal(tc.str).digits(nil)) != tc.exp {
+			t.Fatalf("expected %q, got %q", tc.exp, MustDecimal(tc.str).digits(nil))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package orderbook
+
+import "fmt"
+
+// ChecksumError is returned by Orderbook.Middleware if the checksum of the
+// order book state after applying an update does not match the checksum
+// provided by Kraken. The order book state is invalid at this point and must
+// be rebuilt from a new snapshot. Use errors.As to inspect the diagnostic
+// order book state.
+type ChecksumError struct {
+	// Asks are the top ten ask price levels the checksum got computed from.
+	Asks []Level
+	// Bids are the top ten bid price levels the checksum got computed from.
+	Bids []Level
+	// Computed is the checksum of the current order book state.
+	Computed string
+	// Expected is the checksum provided by Kraken.
+	Expected string
+	// Input is the concatenated string the computed checksum got calculated
+	// from.
+	Input string
+	// Response is the update that caused the checksum mismatch.
+	Response Response
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("current order book checksum (%s) must match desired order book checksum (%s)", e.Computed, e.Expected)
+}
+
+// LevelError is returned by Raw.Response if a price level of a book payload is
+// malformed, e.g. because Kraken truncated the price level array. Use errors.As
+// to inspect the offending price level.
+type LevelError struct {
+	// Err describes why the price level is malformed.
+	Err error
+	// Field is the payload field the price level got read from, one of "a",
+	// "as", "b" and "bs".
+	Field string
+	// Index is the position of the price level within Field, counting from
+	// zero.
+	Index int
+	// Raw are the raw values of the price level.
+	Raw []string
+}
+
+func (e *LevelError) Error() string {
+	return fmt.Sprintf("%s price level %d of %q %q: %s", e.Side(), e.Index, e.Field, e.Raw, e.Err)
+}
+
+// Side returns "ask" for price levels of the fields "a" and "as", and "bid"
+// otherwise.
+func (e *LevelError) Side() string {
+	if e.Field == "a" || e.Field == "as" {
+		return "ask"
+	}
+
+	return "bid"
+}
+
+func (e *LevelError) Unwrap() error {
+	return e.Err
+}
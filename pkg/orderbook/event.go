@@ -0,0 +1,156 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ErrorEvent is sent by Kraken if a request could not be processed, e.g. due
+// to an unknown event type.
+//
+//	{"event":"error","errorMessage":"Unsupported event","reqid":42}
+type ErrorEvent struct {
+	ErrorMessage string `json:"errorMessage"`
+	ReqID        int64  `json:"reqid"`
+}
+
+func (e ErrorEvent) Error() string {
+	return fmt.Sprintf("kraken error: %s", e.ErrorMessage)
+}
+
+// Heartbeat is sent by Kraken about once per second in the absence of any
+// other channel updates.
+//
+//	{"event":"heartbeat"}
+type Heartbeat struct{}
+
+// Pong is sent by Kraken in response to a ping request.
+//
+//	{"event":"pong","reqid":42}
+type Pong struct {
+	ReqID int64 `json:"reqid"`
+}
+
+// Subscription describes the channel of a subscription.
+type Subscription struct {
+	Depth int    `json:"depth,omitempty"`
+	Name  string `json:"name"`
+}
+
+// SubscriptionStatus is sent by Kraken in response to subscribe and
+// unsubscribe requests, once per requested pair. Status is one of
+// "subscribed", "unsubscribed" or "error". In case of an error, e.g. due to an
+// invalid pair or depth, ErrorMessage describes the problem.
+//
+//	{"channelID":560,"channelName":"book-10","event":"subscriptionStatus","pair":"ETH/USD","status":"subscribed","subscription":{"depth":10,"name":"book"}}
+type SubscriptionStatus struct {
+	ChannelID    int64        `json:"channelID"`
+	ChannelName  string       `json:"channelName"`
+	ErrorMessage string       `json:"errorMessage"`
+	Pair         string       `json:"pair"`
+	ReqID        int64        `json:"reqid"`
+	Status       string       `json:"status"`
+	Subscription Subscription `json:"subscription"`
+}
+
+// Err returns an error describing the failed subscription request, or nil if
+// the subscription request succeeded.
+func (s SubscriptionStatus) Err() error {
+	if s.Status != "error" {
+		return nil
+	}
+
+	return fmt.Errorf("subscription to %s for %s failed: %s", s.Subscription.Name, s.Pair, s.ErrorMessage)
+}
+
+// SystemStatus is sent by Kraken on connect and whenever the system status
+// changes. Status is one of "online", "maintenance", "cancel_only",
+// "limit_only" or "post_only".
+//
+//	{"connectionID":8628615390848610000,"event":"systemStatus","status":"online","version":"1.0.0"}
+type SystemStatus struct {
+	ConnectionID json.Number `json:"connectionID"`
+	Status       string      `json:"status"`
+	Version      string      `json:"version"`
+}
+
+// Online returns whether the system is fully operational.
+func (s SystemStatus) Online() bool {
+	return s.Status == "online"
+}
+
+// Dispatcher decodes event messages into their typed representation and calls
+// the respective callback. Callbacks may be nil, in which case the event is
+// decoded and dropped.
+type Dispatcher struct {
+	OnError              func(ErrorEvent)
+	OnHeartbeat          func(Heartbeat)
+	OnPong               func(Pong)
+	OnSubscriptionStatus func(SubscriptionStatus)
+	OnSystemStatus       func(SystemStatus)
+	// OnUnknown is called for all event types not known to the Dispatcher.
+	OnUnknown func(Event)
+}
+
+// Dispatch decodes the given event and calls the respective callback. Dispatch
+// returns an error if the event object cannot be decoded into its typed
+// representation.
+func (d Dispatcher) Dispatch(evn Event) error {
+	switch evn.Event {
+	case "error":
+		var x ErrorEvent
+		err := decode(evn, &x)
+		if err != nil {
+			return err
+		}
+		if d.OnError != nil {
+			d.OnError(x)
+		}
+	case "heartbeat":
+		if d.OnHeartbeat != nil {
+			d.OnHeartbeat(Heartbeat{})
+		}
+	case "pong":
+		var x Pong
+		err := decode(evn, &x)
+		if err != nil {
+			return err
+		}
+		if d.OnPong != nil {
+			d.OnPong(x)
+		}
+	case "subscriptionStatus":
+		var x SubscriptionStatus
+		err := decode(evn, &x)
+		if err != nil {
+			return err
+		}
+		if d.OnSubscriptionStatus != nil {
+			d.OnSubscriptionStatus(x)
+		}
+	case "systemStatus":
+		var x SystemStatus
+		err := decode(evn, &x)
+		if err != nil {
+			return err
+		}
+		if d.OnSystemStatus != nil {
+			d.OnSystemStatus(x)
+		}
+	default:
+		if d.OnUnknown != nil {
+			d.OnUnknown(evn)
+		}
+	}
+
+	return nil
+}
+
+func decode(evn Event, x interface{}) error {
+	err := json.Unmarshal(evn.Raw, x)
+	if err != nil {
+		return fmt.Errorf("cannot decode %s event: %w", evn.Event, err)
+	}
+
+	return nil
+}
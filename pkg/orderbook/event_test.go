@@ -0,0 +1,102 @@
+package orderbook
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Dispatcher_Dispatch(t *testing.T) {
+	var err []error
+	var hea int
+	var pon []Pong
+	var sub []SubscriptionStatus
+	var sys []SystemStatus
+	var unk []Event
+
+	var dis Dispatcher
+	{
+		dis = Dispatcher{
+			OnError:              func(x ErrorEvent) { err = append(err, x) },
+			OnHeartbeat:          func(x Heartbeat) { hea++ },
+			OnPong:               func(x Pong) { pon = append(pon, x) },
+			OnSubscriptionStatus: func(x SubscriptionStatus) { sub = append(sub, x) },
+			OnSystemStatus:       func(x SystemStatus) { sys = append(sys, x) },
+			OnUnknown:            func(x Event) { unk = append(unk, x) },
+		}
+	}
+
+	testCases := []string{
+		`{"connectionID":8628615390848610000,"event":"systemStatus","status":"maintenance","version":"1.9.0"}`,
+		`{"channelID":560,"channelName":"book-25","event":"subscriptionStatus","pair":"ETH/USD","reqid":7,"status":"subscribed","subscription":{"depth":25,"name":"book"}}`,
+		`{"errorMessage":"Currency pair not supported ETH/FOO","event":"subscriptionStatus","pair":"ETH/FOO","status":"error","subscription":{"depth":10,"name":"book"}}`,
+		`{"errorMessage":"Subscription depth not supported","event":"subscriptionStatus","pair":"ETH/USD","status":"error","subscription":{"depth":50,"name":"book"}}`,
+		`{"event":"heartbeat"}`,
+		`{"event":"heartbeat"}`,
+		`{"event":"pong","reqid":42}`,
+		`{"errorMessage":"Unsupported event","event":"error","reqid":43}`,
+		`{"event":"somethingNew"}`,
+	}
+
+	for _, tc := range testCases {
+		msg, e := Decode([]byte(tc))
+		if e != nil {
+			t.Fatal(e)
+		}
+
+		e = dis.Dispatch(*msg.Event)
+		if e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	if len(sys) != 1 || sys[0].Status != "maintenance" || sys[0].Online() || sys[0].ConnectionID.String() != "8628615390848610000" {
+		t.Fatalf("expected maintenance system status, got %#v", sys)
+	}
+	if len(sub) != 3 {
+		t.Fatalf("expected 3 subscription statuses, got %d", len(sub))
+	}
+	if sub[0].Err() != nil || sub[0].ChannelID != 560 || sub[0].ChannelName != "book-25" || sub[0].Subscription.Depth != 25 || sub[0].ReqID != 7 {
+		t.Fatalf("expected successful book-25 subscription, got %#v", sub[0])
+	}
+	if sub[1].Err() == nil || sub[1].Pair != "ETH/FOO" {
+		t.Fatalf("expected invalid pair error, got %#v", sub[1])
+	}
+	if sub[2].Err() == nil || sub[2].Subscription.Depth != 50 {
+		t.Fatalf("expected invalid depth error, got %#v", sub[2])
+	}
+	if hea != 2 {
+		t.Fatalf("expected 2 heartbeats, got %d", hea)
+	}
+	if len(pon) != 1 || pon[0].ReqID != 42 {
+		t.Fatalf("expected pong with reqid 42, got %#v", pon)
+	}
+	if len(err) != 1 || err[0].Error() != "kraken error: Unsupported event" {
+		t.Fatalf("expected error event, got %#v", err)
+	}
+	if len(unk) != 1 || unk[0].Event != "somethingNew" {
+		t.Fatalf("expected unknown event, got %#v", unk)
+	}
+}
+
+func Test_Dispatcher_Dispatch_Malformed(t *testing.T) {
+	testCases := []string{
+		`{"event":"subscriptionStatus","channelID":"560"}`,
+		`{"event":"systemStatus","status":1}`,
+		`{"event":"pong","reqid":"42"}`,
+		`{"event":"error","errorMessage":[]}`,
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			msg, err := Decode([]byte(tc))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = Dispatcher{}.Dispatch(*msg.Event)
+			if err == nil {
+				t.Fatal("expected malformed event to cause an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package orderbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Message is a single decoded message of the Kraken websocket API v1. Every
+// message is either a channel frame or an event object, so that exactly one of
+// Frame and Event is set.
+//
+//	https://docs.kraken.com/websockets/#overview
+type Message struct {
+	Event *Event
+	Frame *Frame
+}
+
+// Frame is a channel message of the Kraken websocket API v1, which is encoded
+// as JSON array of the form [channelID, payload, ..., channelName, pair].
+type Frame struct {
+	// ChannelID is the channel ID of the subscription.
+	ChannelID int64
+	// ChannelName is the channel name including the subscribed depth, e.g.
+	// "book-10".
+	ChannelName string
+	// Pair is the asset pair of the subscription, e.g. "ETH/USD".
+	Pair string
+	// Payload are the raw payload elements in between the channel ID and the
+	// channel name.
+	Payload []json.RawMessage
+}
+
+// Event is an event message of the Kraken websocket API v1, which is encoded as
+// JSON object with an "event" field, e.g. {"event":"heartbeat"}.
+type Event struct {
+	// Event is the event type, e.g. "heartbeat" or "subscriptionStatus".
+	Event string
+	// Raw is the raw event object.
+	Raw json.RawMessage
+}
+
+// Decode parses a single text message received from the Kraken websocket API
+// v1 and classifies it as channel frame or event object.
+func Decode(byt []byte) (Message, error) {
+	var err error
+
+	{
+		byt = bytes.TrimSpace(byt)
+	}
+
+	if len(byt) == 0 {
+		return Message{}, fmt.Errorf("message must not be empty")
+	}
+
+	if byt[0] == '{' {
+		var evn struct {
+			Event *string `json:"event"`
+		}
+
+		{
+			err = json.Unmarshal(byt, &evn)
+			if err != nil {
+				return Message{}, fmt.Errorf("cannot decode event: %w", err)
+			}
+		}
+
+		if evn.Event == nil {
+			return Message{}, fmt.Errorf("event object must contain an event field")
+		}
+
+		return Message{Event: &Event{Event: *evn.Event, Raw: json.RawMessage(byt)}}, nil
+	}
+
+	if byt[0] == '[' {
+		var lis []json.RawMessage
+		{
+			err = json.Unmarshal(byt, &lis)
+			if err != nil {
+				return Message{}, fmt.Errorf("cannot decode frame: %w", err)
+			}
+		}
+
+		if len(lis) < 4 {
+			return Message{}, fmt.Errorf("frame must contain at least 4 elements, got %d", len(lis))
+		}
+
+		var cid int64
+		{
+			cid, err = strconv.ParseInt(string(lis[0]), 10, 64)
+			if err != nil {
+				return Message{}, fmt.Errorf("frame channel ID (%s) must be an integer", lis[0])
+			}
+		}
+
+		var nam string
+		{
+			nam, err = text(lis[len(lis)-2])
+			if err != nil {
+				return Message{}, fmt.Errorf("frame channel name (%s) must be a string", lis[len(lis)-2])
+			}
+		}
+
+		var pai string
+		{
+			pai, err = text(lis[len(lis)-1])
+			if err != nil {
+				return Message{}, fmt.Errorf("frame pair (%s) must be a string", lis[len(lis)-1])
+			}
+		}
+
+		var frm Frame
+		{
+			frm = Frame{
+				ChannelID:   cid,
+				ChannelName: nam,
+				Pair:        pai,
+				Payload:     lis[1 : len(lis)-2],
+			}
+		}
+
+		return Message{Frame: &frm}, nil
+	}
+
+	return Message{}, fmt.Errorf("message must be a JSON array or object, got %q", excerpt(byt))
+}
+
+// Book returns whether the frame belongs to a book channel.
+func (f Frame) Book() bool {
+	return f.ChannelName == "book" || strings.HasPrefix(f.ChannelName, "book-")
+}
+
+// Raw decodes the payload of a book frame. Kraken may send book updates for
+// both sides as two separate payload objects within a single frame, one
+// containing the asks and one containing the bids together with the checksum.
+// Raw merges all payload objects into a single Raw. Raw returns an error if the
+// frame does not belong to a book channel, does not contain any payload
+// object, or if the payload objects provide conflicting checksums.
+func (f Frame) Raw() (Raw, error) {
+	if !f.Book() {
+		return Raw{}, fmt.Errorf("frame channel name (%s) must be a book channel", f.ChannelName)
+	}
+
+	if len(f.Payload) == 0 {
+		return Raw{}, fmt.Errorf("book frame must contain at least 1 payload object")
+	}
+
+	var raw Raw
+
+	for i, x := range f.Payload {
+		var pay Raw
+		{
+			err := json.Unmarshal(x, &pay)
+			if err != nil {
+				return Raw{}, fmt.Errorf("cannot decode book payload %d: %w", i, err)
+			}
+		}
+
+		if pay.C != "" && raw.C != "" && pay.C != raw.C {
+			return Raw{}, fmt.Errorf("book payload %d checksum (%s) must match previous checksum (%s)", i, pay.C, raw.C)
+		}
+
+		{
+			raw.A = merge(raw.A, pay.A)
+			raw.As = merge(raw.As, pay.As)
+			raw.B = merge(raw.B, pay.B)
+			raw.Bs = merge(raw.Bs, pay.Bs)
+		}
+
+		if pay.C != "" {
+			raw.C = pay.C
+		}
+	}
+
+	return raw, nil
+}
+
+// merge appends the price levels of src to dst. Other than append, merge keeps
+// a field that is present but empty in src present, since the presence of the
+// as and bs fields marks a snapshot.
+func merge(dst [][]string, src [][]string) [][]string {
+	if dst == nil && src != nil {
+		dst = [][]string{}
+	}
+
+	return append(dst, src...)
+}
+
+// Response decodes the payload of a book frame via Frame.Raw and converts it
+// into a Response.
+func (f Frame) Response() (Response, error) {
+	var err error
+
+	var raw Raw
+	{
+		raw, err = f.Raw()
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	var rsp Response
+	{
+		rsp, err = raw.Response()
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	return rsp, nil
+}
+
+// text decodes the given JSON string. Other than json.Unmarshal, text rejects
+// null.
+func text(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || raw[0] != '"' {
+		return "", fmt.Errorf("JSON value must be a string")
+	}
+
+	var str string
+	{
+		err := json.Unmarshal(raw, &str)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return str, nil
+}
+
+// excerpt shortens the given message for the purpose of error messages.
+func excerpt(byt []byte) string {
+	if len(byt) > 32 {
+		return string(byt[:32]) + "..."
+	}
+
+	return string(byt)
+}
@@ -0,0 +1,229 @@
+package orderbook
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Decode_Event(t *testing.T) {
+	testCases := []struct {
+		msg string
+		evn string
+	}{
+		{msg: `{"event":"heartbeat"}`, evn: "heartbeat"},
+		{msg: `{"connectionID":8628615390848610000,"event":"systemStatus","status":"online","version":"1.0.0"}`, evn: "systemStatus"},
+		{msg: ` {"channelID":560,"channelName":"book-10","event":"subscriptionStatus","pair":"ETH/USD","status":"subscribed","subscription":{"depth":10,"name":"book"}}` + "\n", evn: "subscriptionStatus"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			msg, err := Decode([]byte(tc.msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if msg.Frame != nil || msg.Event == nil {
+				t.Fatal("expected message to be classified as event")
+			}
+			if msg.Event.Event != tc.evn {
+				t.Fatalf("expected event %s, got %s", tc.evn, msg.Event.Event)
+			}
+		})
+	}
+}
+
+func Test_Decode_Frame(t *testing.T) {
+	testCases := []struct {
+		msg string
+		cid int64
+		nam string
+		pai string
+		snp bool
+	}{
+		{
+			msg: `[560,{"as":[["1289.60000","0.01551181","1669985812.099879"]],"bs":[["1289.59000","0.15508790","1669985824.325921"]]},"book-10","ETH/USD"]`,
+			cid: 560,
+			nam: "book-10",
+			pai: "ETH/USD",
+			snp: true,
+		},
+		{
+			msg: `[1234,{"b":[["21145.10000","0.00000000","1669985828.431310"],["21140.00000","0.30000000","1669985805.713402","r"]],"c":"1028755919"},"book-100","XBT/EUR"]`,
+			cid: 1234,
+			nam: "book-100",
+			pai: "XBT/EUR",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			msg, err := Decode([]byte(tc.msg))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if msg.Event != nil || msg.Frame == nil {
+				t.Fatal("expected message to be classified as frame")
+			}
+			if msg.Frame.ChannelID != tc.cid {
+				t.Fatalf("expected channel ID %d, got %d", tc.cid, msg.Frame.ChannelID)
+			}
+			if msg.Frame.ChannelName != tc.nam {
+				t.Fatalf("expected channel name %s, got %s", tc.nam, msg.Frame.ChannelName)
+			}
+			if msg.Frame.Pair != tc.pai {
+				t.Fatalf("expected pair %s, got %s", tc.pai, msg.Frame.Pair)
+			}
+			if !msg.Frame.Book() {
+				t.Fatal("expected book frame")
+			}
+
+			rsp, err := msg.Frame.Response()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if rsp.IsSnapshot != tc.snp {
+				t.Fatalf("expected snapshot to be %t", tc.snp)
+			}
+		})
+	}
+}
+
+// Test_Frame_Response_Multi aims to cover book frames containing separate
+// payload objects for asks and bids, where only the second payload object
+// contains the checksum. No live capture of such frames is available. The
+// golden multi capture consists of the first messages of the golden success
+// capture instead, where six pairs of consecutive ask and bid updates got
+// merged into single frames. Every merged frame carries the checksum Kraken
+// sent for its bid update, which only matches if both payload objects get
+// applied.
+func Test_Frame_Response_Multi(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	var mul int
+
+	for i, x := range lines("testdata/golden/multi.jsonl") {
+		msg, err := Decode(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rsp, err := msg.Frame.Response()
+		if err != nil {
+			t.Fatalf("frame %d: %s", i, err)
+		}
+
+		if len(msg.Frame.Payload) == 2 {
+			if len(rsp.Asks) == 0 || len(rsp.Bids) == 0 || rsp.CheckSum == "" {
+				t.Fatalf("frame %d: expected asks, bids and checksum, got %d, %d and %q", i, len(rsp.Asks), len(rsp.Bids), rsp.CheckSum)
+			}
+
+			mul++
+		}
+
+		err = ord.Middleware(rsp)
+		if err != nil {
+			t.Fatalf("frame %d: %s", i, err)
+		}
+	}
+
+	if mul != 6 {
+		t.Fatalf("expected 6 frames with two payloads, got %d", mul)
+	}
+}
+
+func Test_Frame_Response_Multi_Checksum(t *testing.T) {
+	msg, err := Decode([]byte(`[560,{"a":[["1272.70000","0.50000000","1669902401.100000"]],"c":"1"},{"b":[["1271.81000","3.25000000","1669902401.100001"]],"c":"2"},"book-10","ETH/USD"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = msg.Frame.Response()
+	if err == nil {
+		t.Fatal("expected conflicting checksums to cause an error")
+	}
+}
+
+func Test_Decode_Frame_Trade(t *testing.T) {
+	msg, err := Decode([]byte(`[337,[["1289.60000","0.01551181","1669985812.099879","b","l",""]],"trade","ETH/USD"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Frame.Book() {
+		t.Fatal("expected trade frame to not be classified as book frame")
+	}
+
+	_, err = msg.Frame.Raw()
+	if err == nil {
+		t.Fatal("expected trade frame to not decode as book payload")
+	}
+}
+
+// Test_Decode_Malformed aims to cover that malformed messages cause errors
+// instead of panics.
+func Test_Decode_Malformed(t *testing.T) {
+	testCases := []string{
+		// Case 000 is empty.
+		``,
+		// Case 001 is not JSON.
+		`[560,{"a":`,
+		// Case 002 is a JSON scalar.
+		`"heartbeat"`,
+		// Case 003 is an object without event field.
+		`{"status":"online"}`,
+		// Case 004 is an array which is too short.
+		`[560,"book-10","ETH/USD"]`,
+		// Case 005 has a channel ID which is not an integer.
+		`["560",{"c":"1"},"book-10","ETH/USD"]`,
+		// Case 006 has a channel name which is not a string.
+		`[560,{"c":"1"},10,"ETH/USD"]`,
+		// Case 007 has a pair which is not a string.
+		`[560,{"c":"1"},"book-10",null]`,
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			_, err := Decode([]byte(tc))
+			if err == nil {
+				t.Fatal("expected malformed message to cause an error")
+			}
+		})
+	}
+}
+
+// Fuzz_Decode ensures that arbitrary websocket messages never cause a panic,
+// neither while decoding nor while converting book frames, and that every
+// decoded message is either a frame or an event.
+func Fuzz_Decode(f *testing.F) {
+	for _, x := range lines("testdata/golden/failure.jsonl")[:3] {
+		f.Add(x)
+	}
+
+	{
+		f.Add([]byte(`{"event":"heartbeat"}`))
+		f.Add([]byte(`{"channelID":560,"channelName":"book-10","event":"subscriptionStatus","pair":"ETH/USD","status":"subscribed","subscription":{"depth":10,"name":"book"}}`))
+		f.Add([]byte(`[560,{"a":[["1272.70000"]]},"book-10","ETH/USD"]`))
+		f.Add([]byte(`[560,{"a":[["1272.70000","1.00000000","1669902401.100000"]]},{"b":[],"c":"1"},"book-10","ETH/USD"]`))
+		f.Add([]byte(`[0,[["1272.70000","0.10000000","1669902401.100000","s","l",""]],"trade","ETH/USD"]`))
+	}
+
+	f.Fuzz(func(t *testing.T, byt []byte) {
+		msg, err := Decode(byt)
+		if err != nil {
+			return
+		}
+
+		if (msg.Event == nil) == (msg.Frame == nil) {
+			t.Fatalf("expected either event or frame, got %#v", msg)
+		}
+
+		if msg.Frame != nil && msg.Frame.Book() {
+			msg.Frame.Response()
+		}
+	})
+}
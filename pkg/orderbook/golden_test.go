@@ -0,0 +1,218 @@
+package orderbook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_Orderbook_Golden runs every capture of the golden testdata directory
+// through the same decoding path the client uses, that is, Decode,
+// Frame.Response and Orderbook.Middleware. A capture <name>.jsonl contains one
+// raw websocket message per line, as received from Kraken, and the order book
+// depth is taken from the channel name of its first book frame. The outcome of
+// the capture is described by <name>.json next to it. Like the client, the
+// harness resets the order book once a message fails, and drops book messages
+// until the next snapshot. Adding a regression case means dropping in a capture
+// together with its expected outcome.
+func Test_Orderbook_Golden(t *testing.T) {
+	var pat []string
+	{
+		var err error
+
+		pat, err = filepath.Glob("testdata/golden/*.jsonl")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(pat) == 0 {
+		t.Fatal("expected golden captures in testdata/golden")
+	}
+
+	for _, x := range pat {
+		t.Run(strings.TrimSuffix(filepath.Base(x), ".jsonl"), func(t *testing.T) {
+			var exp golden
+			{
+				byt, err := os.ReadFile(strings.TrimSuffix(x, ".jsonl") + ".json")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = json.Unmarshal(byt, &exp)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var fal []int
+			var frm int
+			var ord *Orderbook
+
+			for i, y := range lines(x) {
+				msg, err := Decode(y)
+				if err != nil {
+					fal = append(fal, i)
+					continue
+				}
+
+				if msg.Frame == nil || !msg.Frame.Book() {
+					continue
+				}
+
+				if ord == nil {
+					dep, err := strconv.Atoi(strings.TrimPrefix(msg.Frame.ChannelName, "book-"))
+					if err != nil {
+						t.Fatalf("line %d: expected channel name with depth, got %s", i, msg.Frame.ChannelName)
+					}
+
+					ord = New(Config{Dep: dep})
+				}
+
+				rsp, err := msg.Frame.Response()
+				if err != nil {
+					ord.Reset()
+					fal = append(fal, i)
+					continue
+				}
+
+				if !ord.Valid() && !rsp.IsSnapshot {
+					continue
+				}
+
+				err = ord.Middleware(rsp)
+				if err != nil {
+					ord.Reset()
+					fal = append(fal, i)
+					continue
+				}
+
+				{
+					frm++
+				}
+			}
+
+			if ord == nil {
+				t.Fatal("expected capture to contain a book frame")
+			}
+
+			if fmt.Sprint(fal) != fmt.Sprint(exp.Errors) {
+				t.Fatalf("expected errors at lines %v, got %v", exp.Errors, fal)
+			}
+
+			if frm != exp.Frames {
+				t.Fatalf("expected %d applied frames, got %d", exp.Frames, frm)
+			}
+
+			if ord.Valid() != exp.Valid {
+				t.Fatalf("expected valid order book to be %t, got %t", exp.Valid, ord.Valid())
+			}
+
+			if !exp.Valid {
+				if !ord.Empty() {
+					t.Fatal("expected invalid order book to be empty")
+				}
+
+				return
+			}
+
+			if ord.Checksum() != exp.Checksum {
+				t.Fatalf("expected checksum %s, got %s", exp.Checksum, ord.Checksum())
+			}
+
+			ask, _ := ord.BestAsk()
+			if fmt.Sprint(exp.Ask) != fmt.Sprint([]string{ask.Price.String(), ask.Volume.String()}) {
+				t.Fatalf("expected best ask %v, got %s at %s", exp.Ask, ask.Volume, ask.Price)
+			}
+
+			bid, _ := ord.BestBid()
+			if fmt.Sprint(exp.Bid) != fmt.Sprint([]string{bid.Price.String(), bid.Volume.String()}) {
+				t.Fatalf("expected best bid %v, got %s at %s", exp.Bid, bid.Volume, bid.Price)
+			}
+		})
+	}
+}
+
+// golden is the expected outcome of a golden capture.
+type golden struct {
+	// Ask is the price and volume of the best ask after the last message.
+	Ask []string `json:"ask"`
+	// Bid is the price and volume of the best bid after the last message.
+	Bid []string `json:"bid"`
+	// Checksum is the order book checksum after the last message.
+	Checksum string `json:"checksum"`
+	// Errors are the line indices of all messages that must fail, counting
+	// from zero.
+	Errors []int `json:"errors"`
+	// Frames is the number of book messages that must get applied.
+	Frames int `json:"frames"`
+	// Valid is whether the order book must be valid after the last message.
+	// Ask, Bid and Checksum are only verified for valid order books, while
+	// invalid order books must be empty.
+	Valid bool `json:"valid"`
+}
+
+// lines returns the raw websocket messages of the given capture.
+func lines(pat string) [][]byte {
+	var err error
+
+	var fil *os.File
+	{
+		fil, err = os.Open(pat)
+		if err != nil {
+			panic(err)
+		}
+		defer fil.Close()
+	}
+
+	var lin [][]byte
+
+	var sca *bufio.Scanner
+	{
+		sca = bufio.NewScanner(fil)
+		sca.Buffer(nil, 1024*1024)
+	}
+
+	for sca.Scan() {
+		lin = append(lin, append([]byte(nil), sca.Bytes()...))
+	}
+
+	{
+		err = sca.Err()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return lin
+}
+
+// testdatag returns the book messages of the given golden capture.
+func testdatag(nam string) []Response {
+	var res []Response
+
+	for _, x := range lines(filepath.Join("testdata", "golden", nam+".jsonl")) {
+		msg, err := Decode(x)
+		if err != nil {
+			panic(err)
+		}
+
+		if msg.Frame == nil || !msg.Frame.Book() {
+			continue
+		}
+
+		rsp, err := msg.Frame.Response()
+		if err != nil {
+			panic(err)
+		}
+
+		res = append(res, rsp)
+	}
+
+	return res
+}
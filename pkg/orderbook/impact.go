@@ -0,0 +1,183 @@
+package orderbook
+
+import (
+	"fmt"
+)
+
+// Side is the direction of a hypothetical market order. A buy order sweeps the
+// ask side of the order book, while a sell order sweeps the bid side.
+type Side string
+
+const (
+	Buy  Side = "buy"
+	Sell Side = "sell"
+)
+
+// Order is a hypothetical market order used to estimate market impact. Either
+// Base or Quote must be set. Base is the order quantity in the base currency,
+// e.g. ETH for ETH/USD. Quote is the order quantity in the quote currency, e.g.
+// USD for ETH/USD.
+type Order struct {
+	Side  Side
+	Base  Decimal
+	Quote Decimal
+}
+
+// Impact is the estimated outcome of sweeping the order book with an Order.
+type Impact struct {
+	// Average is the volume weighted average fill price, rounded to eight
+	// decimals beyond the pair's price decimals.
+	Average Decimal
+	// Filled is the base quantity that could be filled.
+	Filled Decimal
+	// Levels are the price levels consumed by the order, best price first,
+	// with the volume taken from each price level. The last price level may
+	// only be consumed partially.
+	Levels []Level
+	// Mid is the mid price of the order book before executing the order.
+	Mid Decimal
+	// Notional is the quote quantity paid for buy orders, or received for sell
+	// orders.
+	Notional Decimal
+	// Remainder is the unfilled order quantity, in the currency the order was
+	// given in. For quote orders the remainder may contain a fraction of the
+	// smallest lot size, which cannot be filled by definition.
+	Remainder Decimal
+	// Slippage is the difference between the average fill price and the mid
+	// price in basis points, rounded to four decimals. Slippage is positive if
+	// the average fill price is worse than the mid price.
+	Slippage Decimal
+	// Worst is the worst price touched by the order.
+	Worst Decimal
+}
+
+// Impact simulates sweeping the order book with the given market order without
+// modifying the order book. Impact returns an error if the order is invalid or
+// if either side of the order book is empty. Orders exceeding the available
+// liquidity are filled as far as possible, leaving the rest as Remainder.
+func (o *Orderbook) Impact(ord Order) (Impact, error) {
+	if ord.Side != Buy && ord.Side != Sell {
+		return Impact{}, fmt.Errorf("order side must be %q or %q, got %q", Buy, Sell, ord.Side)
+	}
+
+	if ord.Base.IsZero() == ord.Quote.IsZero() {
+		return Impact{}, fmt.Errorf("order must specify either base or quote quantity, got base %s and quote %s", ord.Base, ord.Quote)
+	}
+
+	if ord.Base.Sign() < 0 || ord.Quote.Sign() < 0 {
+		return Impact{}, fmt.Errorf("order quantity must be positive, got base %s and quote %s", ord.Base, ord.Quote)
+	}
+
+	var lev []Level
+	var mid Decimal
+	var ok bool
+	{
+		o.mut.Lock()
+
+		mid, ok = o.mid()
+
+		if ord.Side == Buy {
+			lev = append(lev, o.ask.lev...)
+		} else {
+			lev = append(lev, o.bid.lev...)
+		}
+
+		o.mut.Unlock()
+	}
+
+	if !ok {
+		return Impact{}, fmt.Errorf("order book must not be empty on either side to estimate market impact")
+	}
+
+	var imp Impact
+	{
+		imp.Mid = mid
+	}
+
+	var rem Decimal
+	if ord.Quote.IsZero() {
+		rem = ord.Base
+	} else {
+		rem = ord.Quote
+	}
+
+	for _, x := range lev {
+		if rem.IsZero() {
+			break
+		}
+
+		var vol Decimal
+		if ord.Quote.IsZero() {
+			vol = minimum(x.Volume, rem)
+			rem = rem.Sub(vol)
+		} else {
+			vol = minimum(x.Volume, lots(rem, x))
+			rem = rem.Sub(vol.Mul(x.Price))
+		}
+
+		if vol.IsZero() {
+			break
+		}
+
+		{
+			imp.Filled = imp.Filled.Add(vol)
+			imp.Notional = imp.Notional.Add(vol.Mul(x.Price))
+			imp.Levels = append(imp.Levels, Level{Price: x.Price, Volume: vol})
+			imp.Worst = x.Price
+		}
+	}
+
+	{
+		imp.Remainder = rem
+	}
+
+	if imp.Filled.IsZero() {
+		return imp, nil
+	}
+
+	// The slippage is calculated from the exact notional, before rounding the
+	// average fill price for presentation. For a buy order paying more than
+	// the mid price is adverse, for a sell order receiving less is adverse.
+
+	var ref Decimal
+	var dif Decimal
+	{
+		ref = imp.Filled.Mul(mid)
+		dif = imp.Notional.Sub(ref)
+	}
+
+	if ord.Side == Sell {
+		dif = dif.Neg()
+	}
+
+	{
+		imp.Average = imp.Notional.Quo(imp.Filled, imp.Worst.Scale()+8)
+		imp.Slippage = dif.Mul(NewDecimal(10000, 0)).Quo(ref, 4)
+	}
+
+	return imp, nil
+}
+
+// lots returns the base quantity that can be bought for the given quote
+// quantity at the price of the given level, rounded down to the pair's lot
+// decimals.
+func lots(quo Decimal, lev Level) Decimal {
+	var vol Decimal
+	{
+		vol = quo.Quo(lev.Price, lev.Volume.Scale())
+	}
+
+	if vol.Mul(lev.Price).Cmp(quo) > 0 {
+		vol = vol.Sub(NewDecimal(1, lev.Volume.Scale()))
+	}
+
+	return vol
+}
+
+func minimum(a Decimal, b Decimal) Decimal {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+
+	return b
+}
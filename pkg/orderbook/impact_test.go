@@ -0,0 +1,143 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+// Test_Orderbook_Impact aims to cover market impact estimation against the
+// final order book state of the success testdata.
+func Test_Orderbook_Impact(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	for _, x := range testdatas() {
+		err := ord.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		ord Order
+		avg string
+		fil string
+		lev int
+		not string
+		rem string
+		slp string
+		wor string
+	}{
+		// Case 000 buys a base quantity consuming the first ask level fully
+		// and the second ask level partially.
+		{
+			ord: Order{Side: Buy, Base: MustDecimal("13")},
+			avg: "1274.4957871298462",
+			fil: "13.00000000",
+			lev: 2,
+			not: "16568.4452326880000",
+			rem: "0.00000000",
+			slp: "5.6587",
+			wor: "1274.87000",
+		},
+		// Case 001 sells a base quantity against the bid side.
+		{
+			ord: Order{Side: Sell, Base: MustDecimal("30")},
+			avg: "1273.0776666666667",
+			fil: "30.00000000",
+			lev: 3,
+			not: "38192.3300000000000",
+			rem: "0.00000000",
+			slp: "5.4745",
+			wor: "1273.06000",
+		},
+		// Case 002 buys a quote quantity, leaving a remainder smaller than
+		// the smallest lot size.
+		{
+			ord: Order{Side: Buy, Quote: MustDecimal("50000")},
+			avg: "1274.7468885561356",
+			fil: "39.22347287",
+			lev: 4,
+			not: "49999.9999993985000",
+			rem: "0.0000006015000",
+			slp: "7.6300",
+			wor: "1274.90000",
+		},
+		// Case 003 buys more than the available liquidity.
+		{
+			ord: Order{Side: Buy, Base: MustDecimal("1000")},
+			avg: "1274.8705801628790",
+			fil: "64.18732661",
+			lev: 10,
+			not: "81830.5343143949000",
+			rem: "935.81267339",
+			slp: "8.6010",
+			wor: "1275.57000",
+		},
+	}
+
+	for i, tc := range testCases {
+		imp, err := ord.Impact(tc.ord)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if imp.Average.String() != tc.avg {
+			t.Fatalf("case %03d: expected average %s, got %s", i, tc.avg, imp.Average)
+		}
+		if imp.Filled.String() != tc.fil {
+			t.Fatalf("case %03d: expected filled %s, got %s", i, tc.fil, imp.Filled)
+		}
+		if len(imp.Levels) != tc.lev {
+			t.Fatalf("case %03d: expected %d levels, got %d", i, tc.lev, len(imp.Levels))
+		}
+		if imp.Notional.String() != tc.not {
+			t.Fatalf("case %03d: expected notional %s, got %s", i, tc.not, imp.Notional)
+		}
+		if imp.Remainder.String() != tc.rem {
+			t.Fatalf("case %03d: expected remainder %s, got %s", i, tc.rem, imp.Remainder)
+		}
+		if imp.Slippage.String() != tc.slp {
+			t.Fatalf("case %03d: expected slippage %s, got %s", i, tc.slp, imp.Slippage)
+		}
+		if imp.Worst.String() != tc.wor {
+			t.Fatalf("case %03d: expected worst price %s, got %s", i, tc.wor, imp.Worst)
+		}
+	}
+}
+
+func Test_Orderbook_Impact_Error(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	{
+		_, err := ord.Impact(Order{Side: Buy, Base: MustDecimal("1")})
+		if err == nil {
+			t.Fatal("expected empty order book to cause an error")
+		}
+	}
+
+	for _, x := range testdatas()[:1] {
+		err := ord.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []Order{
+		{Side: "hold", Base: MustDecimal("1")},
+		{Side: Buy},
+		{Side: Buy, Base: MustDecimal("1"), Quote: MustDecimal("1")},
+		{Side: Sell, Base: MustDecimal("-1")},
+	}
+
+	for i, tc := range testCases {
+		_, err := ord.Impact(tc)
+		if err == nil {
+			t.Fatalf("case %03d: expected invalid order to cause an error", i)
+		}
+	}
+}
@@ -4,103 +4,94 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/crc32"
-	"sort"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 )
 
 // https://docs.kraken.com/websockets/#message-book
 type Orderbook struct {
-	ask map[json.Number]json.Number
-	bid map[json.Number]json.Number
+	ask *side
+	bid *side
+	dep int
 	mut sync.Mutex
+	tim time.Time
+	val bool
 }
 
-func New() *Orderbook {
-	return &Orderbook{}
+func New(con Config) *Orderbook {
+	{
+		con.Verify()
+	}
+
+	return &Orderbook{
+		ask: &side{asc: true},
+		bid: &side{asc: false},
+		dep: con.Dep,
+	}
 }
 
+// Checksum returns the CRC32 checksum of the top ten ask and bid price levels
+// of the current order book state. Checksum only reads from the internal state
+// and can therefore be called at any time, e.g. for diagnostics.
 func (o *Orderbook) Checksum() string {
-	var err error
-
-	// Since the checksum calculation requires asks and bids to be sorted by
-	// price, we need to allocate our internal state from a map to a slice of
-	// price/volume pairs. Below we cast prices only once so that we do not have
-	// to add additional compute during sorting.
-
-	var ask []privol
-	var bid []privol
-
-	for k, v := range o.ask {
-		var pri float64
-		{
-			pri, err = k.Float64()
-			if err != nil {
-				panic(err)
-			}
-		}
-
-		{
-			ask = append(ask, privol{Num: k, Flo: pri, Vol: v})
-		}
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
 	}
 
-	for k, v := range o.bid {
-		var pri float64
-		{
-			pri, err = k.Float64()
-			if err != nil {
-				panic(err)
-			}
-		}
+	return o.checksum()
+}
 
-		{
-			bid = append(bid, privol{Num: k, Flo: pri, Vol: v})
-		}
+// Age returns the time since the order book state last changed, that is, since
+// the last snapshot, update or reset got applied. Age of an order book that was
+// never changed is very large.
+func (o *Orderbook) Age() time.Duration {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
 	}
 
+	return time.Since(o.tim)
+}
+
+func (o *Orderbook) checksum() string {
 	// As per the Kraken documentation, processing order is important. First,
 	// the top ten ask price levels should be processed, sorted by price from
 	// low to high. Then, the top ten bid price levels should be processed,
-	// sorted by price from high to low.
+	// sorted by price from high to low. Both sides are kept sorted in exactly
+	// this order already.
+	//
+	// The final concatenation adds the formatted price and volume without the
+	// decimal point and leading zeros respectively, for each top pair of our
+	// ask and bid sides. The result is then hashed via CRC32, Golang specified
+	// using the IEEE polynomial. Price levels beyond the top ten do not
+	// contribute to the checksum. Removing out of scope price levels is the
+	// responsibility of Orderbook.Truncate.
+
+	sum, _ := o.checksumInput()
+	return sum
+}
 
+// checksumInput returns the checksum together with the concatenated string the
+// checksum got calculated from.
+func (o *Orderbook) checksumInput() (string, string) {
+	var con []byte
 	{
-		sort.SliceStable(ask, func(i, j int) bool { return ask[i].Flo < ask[j].Flo })
-		sort.SliceStable(bid, func(i, j int) bool { return bid[i].Flo > bid[j].Flo })
+		con = make([]byte, 0, 512)
 	}
 
-	// The final concatenation adds the left-trimmed strings of price and volume
-	// respectively, for each top pair from our sorted ask and bid slices. The
-	// result is then hashed via CRC32, Golang specified using the IEEE
-	// polynomial. Note that the code below removes non-essential order book
-	// entries that got pushed out along the price levels. Without removing out
-	// of scope price levels the checksum calculation does not work in its
-	// current form. The implication here is that Orderbook.Checksum modifies
-	// the internal order book state, instead of only reading from it. A cleaner
-	// design could be achieved by sacrificing compute performance.
-
-	var con string
-
-	for i, x := range ask {
-		if i <= 9 {
-			con += trmlft(x.Num.String())
-			con += trmlft(x.Vol.String())
-		} else {
-			delete(o.ask, x.Num)
-		}
+	for _, x := range o.ask.top(10) {
+		con = x.Price.digits(con)
+		con = x.Volume.digits(con)
 	}
 
-	for i, x := range bid {
-		if i <= 9 {
-			con += trmlft(x.Num.String())
-			con += trmlft(x.Vol.String())
-		} else {
-			delete(o.bid, x.Num)
-		}
+	for _, x := range o.bid.top(10) {
+		con = x.Price.digits(con)
+		con = x.Volume.digits(con)
 	}
 
-	return fmt.Sprintf("%d", crc32.ChecksumIEEE([]byte(con)))
+	return strconv.FormatUint(uint64(crc32.ChecksumIEEE(con)), 10), string(con)
 }
 
 func (o *Orderbook) Empty() bool {
@@ -109,7 +100,7 @@ func (o *Orderbook) Empty() bool {
 		defer o.mut.Unlock()
 	}
 
-	return len(o.ask) == 0 && len(o.bid) == 0
+	return o.ask.len() == 0 && o.bid.len() == 0
 }
 
 func (o *Orderbook) MarshalJSON() ([]byte, error) {
@@ -118,96 +109,262 @@ func (o *Orderbook) MarshalJSON() ([]byte, error) {
 		defer o.mut.Unlock()
 	}
 
+	var ask map[string]Decimal
+	var bid map[string]Decimal
+	{
+		ask = map[string]Decimal{}
+		bid = map[string]Decimal{}
+	}
+
+	for _, x := range o.ask.lev {
+		ask[x.Price.String()] = x.Volume
+	}
+
+	for _, x := range o.bid.lev {
+		bid[x.Price.String()] = x.Volume
+	}
+
 	return json.Marshal(&struct {
-		Ask map[json.Number]json.Number `json:"ask"`
-		Bid map[json.Number]json.Number `json:"bid"`
-		Tim time.Time                   `json:"tim"`
+		Ask map[string]Decimal `json:"ask"`
+		Bid map[string]Decimal `json:"bid"`
+		Tim time.Time          `json:"tim"`
 	}{
-		Ask: o.ask,
-		Bid: o.bid,
+		Ask: ask,
+		Bid: bid,
 		Tim: time.Now().UTC().Round(time.Second),
 	})
 }
 
+// Middleware applies the given snapshot or update to the internal order book
+// state and verifies the resulting checksum. Updates must always carry a
+// checksum. Snapshots of the v1 API do not carry a checksum, while snapshots
+// of the v2 API do, in which case the checksum is verified as well.
 func (o *Orderbook) Middleware(upd Response) error {
+	var err error
+
 	{
 		o.mut.Lock()
 		defer o.mut.Unlock()
 	}
 
 	if upd.IsSnapshot {
-		o.Snapshot(upd)
+		err = o.Snapshot(upd)
+		if err != nil {
+			return fmt.Errorf("cannot apply snapshot: %w", err)
+		}
 	} else {
-		{
-			o.Update(upd)
+		err = o.Update(upd)
+		if err != nil {
+			return fmt.Errorf("cannot apply update: %w", err)
 		}
+	}
+
+	{
+		o.Truncate()
+	}
+
+	if upd.IsSnapshot && upd.CheckSum == "" {
+		return nil
+	}
+
+	var sum string
+	var inp string
+	{
+		sum, inp = o.checksumInput()
+	}
 
-		if upd.CheckSum != o.Checksum() {
-			return fmt.Errorf("current order book checksum (%s) must match desired order book checksum (%s)", o.Checksum(), upd.CheckSum)
+	if upd.CheckSum != sum {
+		return &ChecksumError{
+			Asks:     append([]Level(nil), o.ask.top(10)...),
+			Bids:     append([]Level(nil), o.bid.top(10)...),
+			Computed: sum,
+			Expected: upd.CheckSum,
+			Input:    inp,
+			Response: upd,
 		}
 	}
 
 	return nil
 }
 
-func (o *Orderbook) Snapshot(upd Response) {
+// Live returns whether the order book is valid and changed within the given
+// interval. A book that is valid but not live may either belong to a quiet
+// market or to a stalled feed, which only a connection level watchdog can tell
+// apart.
+func (o *Orderbook) Live(dur time.Duration) bool {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.val && time.Since(o.tim) <= dur
+}
+
+// Reset removes all price levels and marks the order book as invalid until the
+// next snapshot got applied. Reset is meant to be called once the order book
+// state is known to be broken, e.g. after a checksum mismatch, so that
+// consumers do not act on stale data while the order book gets rebuilt.
+func (o *Orderbook) Reset() {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	{
+		o.ask = &side{asc: true}
+		o.bid = &side{asc: false}
+		o.tim = time.Now()
+		o.val = false
+	}
+}
+
+// Snapshot replaces the internal order book state with the price levels of the
+// given snapshot. Snapshot returns an error without modifying the internal
+// state if any price level is invalid.
+func (o *Orderbook) Snapshot(upd Response) error {
+	var err error
+
+	{
+		err = verify(upd, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	{
-		o.ask = map[json.Number]json.Number{}
-		o.bid = map[json.Number]json.Number{}
+		o.ask = &side{asc: true}
+		o.bid = &side{asc: false}
 	}
 
 	for _, x := range upd.Asks {
-		o.ask[x.Price] = x.Volume
+		o.ask.set(x.Price, x.Volume)
 	}
 
 	for _, x := range upd.Bids {
-		o.bid[x.Price] = x.Volume
+		o.bid.set(x.Price, x.Volume)
+	}
+
+	{
+		o.tim = time.Now()
+		o.val = true
 	}
+
+	return nil
 }
 
-func (o *Orderbook) Update(upd Response) {
+// Truncate removes all ask and bid price levels that got pushed out of the
+// subscribed depth as configured via Config.Dep. Kraken does not send explicit
+// delete messages for price levels falling out of scope, so without truncation
+// stale levels would move back into the top of the book once better levels get
+// removed.
+func (o *Orderbook) Truncate() {
+	{
+		o.ask.trunc(o.dep)
+		o.bid.trunc(o.dep)
+	}
+}
+
+// Valid returns whether the order book got built from a snapshot and was not
+// reset since.
+func (o *Orderbook) Valid() bool {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.val
+}
+
+// Update applies the price levels of the given update to the internal order
+// book state. Price levels with zero volume are removed. Update returns an error
+// without modifying the internal state if any price level is invalid.
+func (o *Orderbook) Update(upd Response) error {
 	var err error
 
-	for _, x := range upd.Asks {
-		var vol float64
-		{
-			vol, err = x.Volume.Float64()
-			if err != nil {
-				panic(err)
-			}
+	{
+		err = verify(upd, false)
+		if err != nil {
+			return err
 		}
+	}
 
-		if vol == 0 {
-			delete(o.ask, x.Price)
+	for _, x := range upd.Asks {
+		if x.Volume.IsZero() {
+			o.ask.del(x.Price)
 		} else {
-			o.ask[x.Price] = x.Volume
+			o.ask.set(x.Price, x.Volume)
 		}
 	}
 
 	for _, x := range upd.Bids {
-		var vol float64
-		{
-			vol, err = x.Volume.Float64()
-			if err != nil {
-				panic(err)
+		if x.Volume.IsZero() {
+			o.bid.del(x.Price)
+		} else {
+			o.bid.set(x.Price, x.Volume)
+		}
+	}
+
+	{
+		o.tim = time.Now()
+	}
+
+	return nil
+}
+
+// verify ensures that all prices of the given response are positive. Volumes
+// must be positive for snapshots, while updates may use zero volume in order
+// to remove price levels. Snapshots must further not be crossed, that is, all
+// bids must be below all asks.
+func verify(upd Response, snp bool) error {
+	for i, x := range upd.Asks {
+		err := verifyObject(x, snp)
+		if err != nil {
+			return fmt.Errorf("ask %d: %w", i, err)
+		}
+	}
+
+	for i, x := range upd.Bids {
+		err := verifyObject(x, snp)
+		if err != nil {
+			return fmt.Errorf("bid %d: %w", i, err)
+		}
+	}
+
+	if snp {
+		var ask *Decimal
+		for i, x := range upd.Asks {
+			if ask == nil || x.Price.Cmp(*ask) < 0 {
+				ask = &upd.Asks[i].Price
 			}
 		}
 
-		if vol == 0 {
-			delete(o.bid, x.Price)
-		} else {
-			o.bid[x.Price] = x.Volume
+		var bid *Decimal
+		for i, x := range upd.Bids {
+			if bid == nil || x.Price.Cmp(*bid) > 0 {
+				bid = &upd.Bids[i].Price
+			}
+		}
+
+		if ask != nil && bid != nil && bid.Cmp(*ask) >= 0 {
+			return fmt.Errorf("best bid (%s) must be below best ask (%s)", *bid, *ask)
 		}
 	}
-}
 
-type privol struct {
-	Num json.Number
-	Flo float64
-	Vol json.Number
+	return nil
 }
 
-func trmlft(str string) string {
-	x := strings.TrimLeft(strings.Replace(str, ".", "", -1), "0")
-	return x
+func verifyObject(obj Object, snp bool) error {
+	if obj.Price.Sign() <= 0 {
+		return fmt.Errorf("price (%s) must be positive", obj.Price)
+	}
+
+	if snp && obj.Volume.Sign() <= 0 {
+		return fmt.Errorf("volume (%s) must be positive", obj.Volume)
+	}
+
+	if obj.Volume.Sign() < 0 {
+		return fmt.Errorf("volume (%s) must not be negative", obj.Volume)
+	}
+
+	return nil
 }
@@ -0,0 +1,134 @@
+package orderbook
+
+// Level is a single price level of the order book.
+type Level struct {
+	Price  Decimal
+	Volume Decimal
+}
+
+// Asks returns a copy of the best n ask price levels, sorted by price from low
+// to high. Less than n price levels are returned if the order book does not
+// contain enough ask price levels, and none if n is not positive.
+func (o *Orderbook) Asks(n int) []Level {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return append([]Level(nil), o.ask.top(n)...)
+}
+
+// BestAsk returns the ask price level with the lowest price. The returned bool
+// is false if there are no ask price levels.
+func (o *Orderbook) BestAsk() (Level, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.ask.best()
+}
+
+// BestBid returns the bid price level with the highest price. The returned bool
+// is false if there are no bid price levels.
+func (o *Orderbook) BestBid() (Level, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.bid.best()
+}
+
+// Bids returns a copy of the best n bid price levels, sorted by price from high
+// to low. Less than n price levels are returned if the order book does not
+// contain enough bid price levels, and none if n is not positive.
+func (o *Orderbook) Bids(n int) []Level {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return append([]Level(nil), o.bid.top(n)...)
+}
+
+// MidPrice returns the exact arithmetic mean of the best ask and best bid
+// price. The returned bool is false if either side of the order book is empty.
+func (o *Orderbook) MidPrice() (Decimal, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.mid()
+}
+
+// RelativeSpread returns the absolute spread divided by the mid price, rounded
+// to sca decimals. Multiply by 10,000 to get the spread in basis points. The
+// returned bool is false if either side of the order book is empty, or if sca
+// is negative.
+func (o *Orderbook) RelativeSpread(sca int32) (Decimal, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	if sca < 0 {
+		return Decimal{}, false
+	}
+
+	spr, ok := o.spread()
+	if !ok {
+		return Decimal{}, false
+	}
+
+	mid, ok := o.mid()
+	if !ok || mid.IsZero() {
+		return Decimal{}, false
+	}
+
+	return spr.Quo(mid, sca), true
+}
+
+// Spread returns the absolute difference between the best ask and best bid
+// price. The returned bool is false if either side of the order book is empty.
+func (o *Orderbook) Spread() (Decimal, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.spread()
+}
+
+func (o *Orderbook) mid() (Decimal, bool) {
+	ask, ok := o.ask.best()
+	if !ok {
+		return Decimal{}, false
+	}
+
+	bid, ok := o.bid.best()
+	if !ok {
+		return Decimal{}, false
+	}
+
+	return ask.Price.Add(bid.Price).Mul(half), true
+}
+
+func (o *Orderbook) spread() (Decimal, bool) {
+	ask, ok := o.ask.best()
+	if !ok {
+		return Decimal{}, false
+	}
+
+	bid, ok := o.bid.best()
+	if !ok {
+		return Decimal{}, false
+	}
+
+	return ask.Price.Sub(bid.Price), true
+}
+
+// half is used to calculate exact means, since multiplying by 0.5 only adds a
+// single decimal, while dividing by 2 would require rounding.
+var half = NewDecimal(5, 1)
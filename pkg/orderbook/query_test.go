@@ -0,0 +1,149 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+func Test_Orderbook_Query_Empty(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	if _, ok := ord.BestAsk(); ok {
+		t.Fatal("expected no best ask for empty order book")
+	}
+	if _, ok := ord.BestBid(); ok {
+		t.Fatal("expected no best bid for empty order book")
+	}
+	if _, ok := ord.Spread(); ok {
+		t.Fatal("expected no spread for empty order book")
+	}
+	if _, ok := ord.RelativeSpread(8); ok {
+		t.Fatal("expected no relative spread for empty order book")
+	}
+	if _, ok := ord.MidPrice(); ok {
+		t.Fatal("expected no mid price for empty order book")
+	}
+	if len(ord.Asks(10)) != 0 || len(ord.Bids(10)) != 0 {
+		t.Fatal("expected no price levels for empty order book")
+	}
+}
+
+// Test_Orderbook_Query_Negative aims to cover that querying a negative number
+// of price levels returns no price levels instead of panicking.
+func Test_Orderbook_Query_Negative(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	{
+		err := ord.Middleware(testdatas()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(ord.Asks(-1)) != 0 || len(ord.Bids(-1)) != 0 {
+		t.Fatalf("expected no price levels, got %d and %d", len(ord.Asks(-1)), len(ord.Bids(-1)))
+	}
+}
+
+// Test_Orderbook_Query_Success aims to cover the top of book query API while
+// processing the success testdata. After every message the query results must
+// be consistent with each other, and after the last message they must match
+// the final order book state.
+func Test_Orderbook_Query_Success(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	for i, x := range testdatas() {
+		err := ord.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ask, ok := ord.BestAsk()
+		if !ok {
+			t.Fatalf("message %d: expected best ask", i)
+		}
+		bid, ok := ord.BestBid()
+		if !ok {
+			t.Fatalf("message %d: expected best bid", i)
+		}
+
+		var asks []Level
+		var bids []Level
+		{
+			asks = ord.Asks(10)
+			bids = ord.Bids(10)
+		}
+
+		if len(asks) != 10 || len(bids) != 10 {
+			t.Fatalf("message %d: expected 10 ask and bid levels, got %d and %d", i, len(asks), len(bids))
+		}
+		if asks[0].Price.Cmp(ask.Price) != 0 || bids[0].Price.Cmp(bid.Price) != 0 {
+			t.Fatalf("message %d: expected top levels to match best ask and bid", i)
+		}
+		if bid.Price.Cmp(ask.Price) >= 0 {
+			t.Fatalf("message %d: expected best bid %s below best ask %s", i, bid.Price, ask.Price)
+		}
+
+		for j := 1; j < 10; j++ {
+			if asks[j-1].Price.Cmp(asks[j].Price) >= 0 {
+				t.Fatalf("message %d: expected asks sorted from low to high", i)
+			}
+			if bids[j-1].Price.Cmp(bids[j].Price) <= 0 {
+				t.Fatalf("message %d: expected bids sorted from high to low", i)
+			}
+		}
+
+		spr, _ := ord.Spread()
+		if spr.Cmp(ask.Price.Sub(bid.Price)) != 0 {
+			t.Fatalf("message %d: expected spread %s, got %s", i, ask.Price.Sub(bid.Price), spr)
+		}
+
+		mid, _ := ord.MidPrice()
+		if mid.Add(mid).Cmp(ask.Price.Add(bid.Price)) != 0 {
+			t.Fatalf("message %d: expected mid price %s to be centered between %s and %s", i, mid, bid.Price, ask.Price)
+		}
+	}
+
+	ask, _ := ord.BestAsk()
+	if ask.Price.String() != "1274.47000" || ask.Volume.String() != "12.16191828" {
+		t.Fatalf("expected best ask 12.16191828@1274.47000, got %s@%s", ask.Volume, ask.Price)
+	}
+
+	bid, _ := ord.BestBid()
+	if bid.Price.String() != "1273.08000" || bid.Volume.String() != "25.25000000" {
+		t.Fatalf("expected best bid 25.25000000@1273.08000, got %s@%s", bid.Volume, bid.Price)
+	}
+
+	spr, _ := ord.Spread()
+	if spr.String() != "1.39000" {
+		t.Fatalf("expected spread 1.39000, got %s", spr)
+	}
+
+	mid, _ := ord.MidPrice()
+	if mid.String() != "1273.775000" {
+		t.Fatalf("expected mid price 1273.775000, got %s", mid)
+	}
+
+	rel, _ := ord.RelativeSpread(8)
+	if rel.String() != "0.00109124" {
+		t.Fatalf("expected relative spread 0.00109124, got %s", rel)
+	}
+
+	bids := ord.Bids(3)
+	if bids[1].Price.String() != "1273.07000" || bids[2].Price.String() != "1273.06000" {
+		t.Fatalf("expected second and third bid at 1273.07000 and 1273.06000, got %s and %s", bids[1].Price, bids[2].Price)
+	}
+
+	asks := ord.Asks(3)
+	if asks[1].Price.String() != "1274.87000" || asks[2].Price.String() != "1274.88000" {
+		t.Fatalf("expected second and third ask at 1274.87000 and 1274.88000, got %s and %s", asks[1].Price, asks[2].Price)
+	}
+}
@@ -1,6 +1,10 @@
 package orderbook
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
 type Raw struct {
 	A  [][]string `json:"a"`
@@ -10,52 +14,62 @@ type Raw struct {
 	C  string     `json:"c"`
 }
 
-func (r Raw) Response() Response {
+// Response converts the raw book message into a Response. Response returns a
+// *LevelError if any price level is malformed. The message is a snapshot if it
+// carries the as or bs field, even if empty, since snapshots of illiquid pairs
+// may have only one side.
+func (r Raw) Response() (Response, error) {
+	var err error
+
 	var ask []Object
 	var bid []Object
 
-	for _, x := range r.A {
-		ask = append(ask, Object{
-			Price:     json.Number(x[0]),
-			Volume:    json.Number(x[1]),
-			Time:      json.Number(x[2]),
-			Republish: republish(x),
-		})
+	{
+		ask, err = objects(ask, "a", r.A)
+		if err != nil {
+			return Response{}, err
+		}
+	}
+
+	{
+		ask, err = objects(ask, "as", r.As)
+		if err != nil {
+			return Response{}, err
+		}
 	}
 
-	for _, x := range r.As {
-		ask = append(ask, Object{
-			Price:     json.Number(x[0]),
-			Volume:    json.Number(x[1]),
-			Time:      json.Number(x[2]),
-			Republish: republish(x),
-		})
+	{
+		bid, err = objects(bid, "b", r.B)
+		if err != nil {
+			return Response{}, err
+		}
 	}
 
-	for _, x := range r.B {
-		bid = append(bid, Object{
-			Price:     json.Number(x[0]),
-			Volume:    json.Number(x[1]),
-			Time:      json.Number(x[2]),
-			Republish: republish(x),
-		})
+	{
+		bid, err = objects(bid, "bs", r.Bs)
+		if err != nil {
+			return Response{}, err
+		}
 	}
 
-	for _, x := range r.Bs {
-		bid = append(bid, Object{
-			Price:     json.Number(x[0]),
-			Volume:    json.Number(x[1]),
-			Time:      json.Number(x[2]),
-			Republish: republish(x),
-		})
+	if r.C != "" {
+		_, err = strconv.ParseUint(r.C, 10, 32)
+		if err != nil {
+			return Response{}, fmt.Errorf("checksum %q must be an unsigned 32 bit integer", r.C)
+		}
 	}
 
-	return Response{
-		Asks:       ask,
-		Bids:       bid,
-		CheckSum:   r.C,
-		IsSnapshot: len(r.As) != 0 && len(r.Bs) != 0 && r.C == "",
+	var res Response
+	{
+		res = Response{
+			Asks:       ask,
+			Bids:       bid,
+			CheckSum:   r.C,
+			IsSnapshot: r.As != nil || r.Bs != nil,
+		}
 	}
+
+	return res, nil
 }
 
 type Response struct {
@@ -65,15 +79,82 @@ type Response struct {
 	IsSnapshot bool
 }
 
+// Object is a single price level as provided by Kraken. Price and Volume keep
+// the pair's price and lot decimals as sent.
 type Object struct {
-	Price     json.Number
-	Volume    json.Number
+	Price     Decimal
+	Volume    Decimal
 	Time      json.Number
 	Republish bool
 }
 
+// object parses a single price level of the form [price, volume, timestamp].
+// Updates may carry "r" as optional fourth field, flagging the price level as
+// republished. Any further fields are ignored, so that fields added by Kraken
+// in the future do not break parsing.
+func object(raw []string) (Object, error) {
+	var err error
+
+	if len(raw) < 3 {
+		return Object{}, fmt.Errorf("must have at least 3 fields, got %d", len(raw))
+	}
+
+	var pri Decimal
+	{
+		pri, err = ParseDecimal(raw[0])
+		if err != nil {
+			return Object{}, fmt.Errorf("price: %w", err)
+		}
+	}
+
+	var vol Decimal
+	{
+		vol, err = ParseDecimal(raw[1])
+		if err != nil {
+			return Object{}, fmt.Errorf("volume: %w", err)
+		}
+	}
+
+	{
+		_, err = ParseDecimal(raw[2])
+		if err != nil {
+			return Object{}, fmt.Errorf("timestamp: %w", err)
+		}
+	}
+
+	var obj Object
+	{
+		obj = Object{
+			Price:     pri,
+			Volume:    vol,
+			Time:      json.Number(raw[2]),
+			Republish: republish(raw),
+		}
+	}
+
+	return obj, nil
+}
+
+// objects parses the given price levels of the given payload field and
+// appends them to all. objects returns a *LevelError for the first malformed
+// price level.
+func objects(all []Object, fie string, lev [][]string) ([]Object, error) {
+	for i, x := range lev {
+		obj, err := object(x)
+		if err != nil {
+			return nil, &LevelError{Err: err, Field: fie, Index: i, Raw: x}
+		}
+
+		{
+			all = append(all, obj)
+		}
+	}
+
+	return all, nil
+}
+
 func republish(raw []string) bool {
-	if len(raw) == 4 && raw[3] == "r" {
+	if len(raw) >= 4 && raw[3] == "r" {
 		return true
 	}
 
@@ -0,0 +1,152 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_Raw_Response(t *testing.T) {
+	var raw Raw
+	{
+		err := json.Unmarshal([]byte(`{"a":[["1289.60000","0.00000000","1669985812.099879"],["1290.10000","4.33660106","1669985825.005016","r"]],"b":[["1289.50000","1.00000000","1669985825.005017","","future"]],"c":"820124158"}`), &raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rsp, err := raw.Response()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rsp.IsSnapshot {
+		t.Fatal("expected update message")
+	}
+	if len(rsp.Asks) != 2 || len(rsp.Bids) != 1 {
+		t.Fatalf("expected 2 asks and 1 bid, got %d and %d", len(rsp.Asks), len(rsp.Bids))
+	}
+	if rsp.Asks[1].Price.String() != "1290.10000" || rsp.Asks[1].Volume.String() != "4.33660106" {
+		t.Fatalf("expected ask 4.33660106@1290.10000, got %s@%s", rsp.Asks[1].Volume, rsp.Asks[1].Price)
+	}
+	if rsp.Asks[0].Republish || !rsp.Asks[1].Republish || rsp.Bids[0].Republish {
+		t.Fatal("expected only the second ask to be republished")
+	}
+	if rsp.Bids[0].Time != "1669985825.005017" {
+		t.Fatalf("expected extra fields to be ignored, got bid time %s", rsp.Bids[0].Time)
+	}
+	if rsp.CheckSum != "820124158" {
+		t.Fatalf("expected checksum 820124158, got %s", rsp.CheckSum)
+	}
+}
+
+// Test_Raw_Response_Malformed aims to cover that malformed raw frames cause
+// errors instead of panics, and that malformed price levels are reported
+// together with their side, field and index.
+func Test_Raw_Response_Malformed(t *testing.T) {
+	testCases := []struct {
+		raw string
+		fie string
+		ind int
+	}{
+		// Case 000 contains a price level without timestamp.
+		{raw: `{"a":[["1289.60000","0.00000000"]],"c":"820124158"}`, fie: "a", ind: 0},
+		// Case 001 contains an empty price level.
+		{raw: `{"bs":[[]],"as":[["1289.60000","0.01551181","1669985812.099879"]]}`, fie: "bs", ind: 0},
+		// Case 002 contains a price which is not a decimal number.
+		{raw: `{"b":[["1289,60000","0.01551181","1669985812.099879"]],"c":"820124158"}`, fie: "b", ind: 0},
+		// Case 003 contains a volume which is not a decimal number.
+		{raw: `{"as":[["1289.60000","NaN","1669985812.099879"]],"bs":[["1289.50000","0.01551181","1669985812.099879"]]}`, fie: "as", ind: 0},
+		// Case 004 contains a checksum which is not an unsigned integer.
+		{raw: `{"a":[["1289.60000","0.01551181","1669985812.099879"]],"c":"-1"}`},
+		// Case 005 contains a truncated second price level.
+		{raw: `{"b":[["1289.60000","0.01551181","1669985812.099879"],["1289.50000"]],"c":"820124158"}`, fie: "b", ind: 1},
+		// Case 006 contains a timestamp which is not a decimal number.
+		{raw: `{"a":[["1289.60000","0.01551181","r"]],"c":"820124158"}`, fie: "a", ind: 0},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var raw Raw
+			{
+				err := json.Unmarshal([]byte(tc.raw), &raw)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := raw.Response()
+			if err == nil {
+				t.Fatal("expected malformed raw frame to cause an error")
+			}
+
+			var lev *LevelError
+			if errors.As(err, &lev) != (tc.fie != "") {
+				t.Fatalf("expected level error to be %t, got %s", tc.fie != "", err)
+			}
+
+			if lev != nil && (lev.Field != tc.fie || lev.Index != tc.ind) {
+				t.Fatalf("expected price level %d of %q, got %d of %q", tc.ind, tc.fie, lev.Index, lev.Field)
+			}
+
+			if lev != nil && (lev.Side() == "ask") != (tc.fie[0] == 'a') {
+				t.Fatalf("expected price level of %q to not be an %s", tc.fie, lev.Side())
+			}
+		})
+	}
+}
+
+// Fuzz_Raw_Response ensures that arbitrary book payloads never cause a panic,
+// and that every price level of a successfully decoded payload is retained.
+func Fuzz_Raw_Response(f *testing.F) {
+	for _, x := range lines("testdata/depth-100.jsonl")[1:3] {
+		f.Add(x)
+	}
+
+	{
+		f.Add([]byte(`{"a":[["1289.60000","0.00000000","1669985812.099879"],["1290.10000","4.33660106","1669985825.005016","r"]],"c":"820124158"}`))
+		f.Add([]byte(`{"b":[["1289.60000","0.00000000"]],"c":"820124158"}`))
+		f.Add([]byte(`{"as":[[]],"bs":[["1289.60000"]]}`))
+		f.Add([]byte(`{"a":[["1289.60000","1.00000000","1669985812.099879","r","x"]]}`))
+	}
+
+	f.Fuzz(func(t *testing.T, byt []byte) {
+		var raw Raw
+		{
+			err := json.Unmarshal(byt, &raw)
+			if err != nil {
+				return
+			}
+		}
+
+		rsp, err := raw.Response()
+		if err != nil {
+			return
+		}
+
+		if len(rsp.Asks) != len(raw.A)+len(raw.As) || len(rsp.Bids) != len(raw.B)+len(raw.Bs) {
+			t.Fatalf("expected %d asks and %d bids, got %d and %d", len(raw.A)+len(raw.As), len(raw.B)+len(raw.Bs), len(rsp.Asks), len(rsp.Bids))
+		}
+
+		var lev [][]string
+		{
+			lev = append(lev, raw.A...)
+			lev = append(lev, raw.As...)
+			lev = append(lev, raw.B...)
+			lev = append(lev, raw.Bs...)
+		}
+
+		var obj []Object
+		{
+			obj = append(obj, rsp.Asks...)
+			obj = append(obj, rsp.Bids...)
+		}
+
+		for i, x := range lev {
+			if obj[i].Republish != (len(x) >= 4 && x[3] == "r") {
+				t.Fatalf("price level %q: expected republish to be %t, got %t", x, !obj[i].Republish, obj[i].Republish)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package orderbook
+
+import (
+	"sort"
+)
+
+// side keeps the price levels of one side of the order book sorted from the
+// best to the worst price. That is, asks are kept sorted by price from low to
+// high, and bids are kept sorted by price from high to low. Price levels are
+// looked up using binary search over their decimal price. Since Kraken
+// limits the number of price levels per side to the subscribed depth, moving
+// the elements of a contiguous slice on insertion and deletion is cheaper than
+// maintaining any pointer based tree structure.
+type side struct {
+	asc bool
+	lev []Level
+}
+
+// best returns the price level with the best price, if any.
+func (s *side) best() (Level, bool) {
+	if len(s.lev) == 0 {
+		return Level{}, false
+	}
+
+	return s.lev[0], true
+}
+
+// del removes the price level of the given price, if any.
+func (s *side) del(pri Decimal) {
+	i, f := s.search(pri)
+	if f {
+		s.lev = append(s.lev[:i], s.lev[i+1:]...)
+	}
+}
+
+func (s *side) len() int {
+	return len(s.lev)
+}
+
+// search returns the index of the price level with the given price and whether
+// that price level exists. If the price level does not exist, the returned
+// index is the position at which it would have to be inserted.
+func (s *side) search(pri Decimal) (int, bool) {
+	i := sort.Search(len(s.lev), func(i int) bool {
+		if s.asc {
+			return s.lev[i].Price.Cmp(pri) >= 0
+		}
+
+		return s.lev[i].Price.Cmp(pri) <= 0
+	})
+
+	return i, i < len(s.lev) && s.lev[i].Price.Cmp(pri) == 0
+}
+
+// set inserts or replaces the price level of the given price.
+func (s *side) set(pri Decimal, vol Decimal) {
+	i, f := s.search(pri)
+	if f {
+		s.lev[i].Volume = vol
+		return
+	}
+
+	{
+		s.lev = append(s.lev, Level{})
+		copy(s.lev[i+1:], s.lev[i:])
+		s.lev[i] = Level{Price: pri, Volume: vol}
+	}
+}
+
+// top returns the best n price levels, or all price levels if there are less
+// than n, or none if n is negative. The returned slice shares memory with the
+// internal state.
+func (s *side) top(n int) []Level {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(s.lev) {
+		n = len(s.lev)
+	}
+
+	return s.lev[:n]
+}
+
+// trunc removes all price levels beyond the best n.
+func (s *side) trunc(n int) {
+	if n < len(s.lev) {
+		s.lev = s.lev[:n]
+	}
+}
@@ -0,0 +1,102 @@
+package orderbook
+
+// Signals are short horizon signals derived from the top n price levels of
+// either side of the order book, all taken from the same order book state.
+type Signals struct {
+	// DepthMid is the arithmetic mean of the volume weighted average prices
+	// of the top n ask and the top n bid price levels.
+	DepthMid Decimal
+	// Imbalance is the volume imbalance of the top n price levels, defined as
+	// (bidVol-askVol)/(bidVol+askVol). Imbalance ranges from -1 to +1, where
+	// positive values indicate more volume on the bid side.
+	Imbalance Decimal
+	// Microprice is the best ask and best bid price weighted by the volume of
+	// the top n price levels of the opposite side, defined as
+	// (bestBid*askVol+bestAsk*bidVol)/(bidVol+askVol). For n equal to one,
+	// this is the classic microprice.
+	Microprice Decimal
+}
+
+// Signals computes the order book signals over the top n price levels, each
+// rounded to sca decimals. Signals are computed under the same lock that
+// Orderbook.Middleware uses to apply updates, so that all values reflect the
+// same checksum verified order book state. The returned bool is false if
+// either side of the order book is empty, if n is not positive or if sca is
+// negative.
+func (o *Orderbook) Signals(n int, sca int32) (Signals, bool) {
+	{
+		o.mut.Lock()
+		defer o.mut.Unlock()
+	}
+
+	return o.signals(n, sca)
+}
+
+// DepthMid is a shortcut for the DepthMid field returned by Orderbook.Signals.
+func (o *Orderbook) DepthMid(n int, sca int32) (Decimal, bool) {
+	sig, ok := o.Signals(n, sca)
+	return sig.DepthMid, ok
+}
+
+// Imbalance is a shortcut for the Imbalance field returned by
+// Orderbook.Signals.
+func (o *Orderbook) Imbalance(n int, sca int32) (Decimal, bool) {
+	sig, ok := o.Signals(n, sca)
+	return sig.Imbalance, ok
+}
+
+// Microprice is a shortcut for the Microprice field returned by
+// Orderbook.Signals.
+func (o *Orderbook) Microprice(n int, sca int32) (Decimal, bool) {
+	sig, ok := o.Signals(n, sca)
+	return sig.Microprice, ok
+}
+
+func (o *Orderbook) signals(n int, sca int32) (Signals, bool) {
+	if n <= 0 || sca < 0 {
+		return Signals{}, false
+	}
+
+	var ask []Level
+	var bid []Level
+	{
+		ask = o.ask.top(n)
+		bid = o.bid.top(n)
+	}
+
+	if len(ask) == 0 || len(bid) == 0 {
+		return Signals{}, false
+	}
+
+	var askVol, askNot Decimal
+	var bidVol, bidNot Decimal
+
+	for _, x := range ask {
+		askVol = askVol.Add(x.Volume)
+		askNot = askNot.Add(x.Price.Mul(x.Volume))
+	}
+
+	for _, x := range bid {
+		bidVol = bidVol.Add(x.Volume)
+		bidNot = bidNot.Add(x.Price.Mul(x.Volume))
+	}
+
+	// All signals are calculated exactly up to a single division, so that
+	// rounding happens only once. The depth weighted mid in particular is
+	// (bidNot/bidVol+askNot/askVol)/2, which we rewrite using the common
+	// denominator 2*bidVol*askVol.
+
+	var tot Decimal
+	{
+		tot = bidVol.Add(askVol)
+	}
+
+	var sig Signals
+	{
+		sig.Imbalance = bidVol.Sub(askVol).Quo(tot, sca)
+		sig.Microprice = bid[0].Price.Mul(askVol).Add(ask[0].Price.Mul(bidVol)).Quo(tot, sca)
+		sig.DepthMid = bidNot.Mul(askVol).Add(askNot.Mul(bidVol)).Quo(bidVol.Mul(askVol).Mul(NewDecimal(2, 0)), sca)
+	}
+
+	return sig, true
+}
@@ -0,0 +1,100 @@
+package orderbook
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Test_Orderbook_Signals aims to cover the order book signals for different
+// numbers of price levels against the final order book state of the success
+// testdata.
+func Test_Orderbook_Signals(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	if _, ok := ord.Signals(10, 8); ok {
+		t.Fatal("expected no signals for empty order book")
+	}
+
+	for _, x := range testdatas() {
+		err := ord.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		n   int
+		imb string
+		mic string
+		dep string
+	}{
+		{n: 1, imb: "0.34983723", mic: "1274.01813687", dep: "1273.77500000"},
+		{n: 5, imb: "0.00590048", mic: "1273.77910084", dep: "1273.92161876"},
+		{n: 10, imb: "0.08006168", mic: "1273.83064286", dep: "1273.95505088"},
+		{n: 50, imb: "0.08006168", mic: "1273.83064286", dep: "1273.95505088"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", tc.n), func(t *testing.T) {
+			sig, ok := ord.Signals(tc.n, 8)
+			if !ok {
+				t.Fatal("expected signals")
+			}
+
+			if sig.Imbalance.String() != tc.imb {
+				t.Fatalf("expected imbalance %s, got %s", tc.imb, sig.Imbalance)
+			}
+			if sig.Microprice.String() != tc.mic {
+				t.Fatalf("expected microprice %s, got %s", tc.mic, sig.Microprice)
+			}
+			if sig.DepthMid.String() != tc.dep {
+				t.Fatalf("expected depth mid %s, got %s", tc.dep, sig.DepthMid)
+			}
+
+			imb, _ := ord.Imbalance(tc.n, 8)
+			mic, _ := ord.Microprice(tc.n, 8)
+			dep, _ := ord.DepthMid(tc.n, 8)
+			if imb.Cmp(sig.Imbalance) != 0 || mic.Cmp(sig.Microprice) != 0 || dep.Cmp(sig.DepthMid) != 0 {
+				t.Fatal("expected shortcuts to match signals")
+			}
+		})
+	}
+}
+
+// Test_Orderbook_Signals_Invalid aims to cover that signals are not available
+// for a non positive number of price levels or a negative scale.
+func Test_Orderbook_Signals_Invalid(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	{
+		err := ord.Middleware(testdatas()[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		n   int
+		sca int32
+	}{
+		{n: 0, sca: 8},
+		{n: -1, sca: 8},
+		{n: 10, sca: -1},
+	}
+
+	for i, tc := range testCases {
+		if _, ok := ord.Signals(tc.n, tc.sca); ok {
+			t.Fatalf("test case %d: expected no signals", i)
+		}
+	}
+
+	if _, ok := ord.RelativeSpread(-1); ok {
+		t.Fatal("expected no relative spread for negative scale")
+	}
+}
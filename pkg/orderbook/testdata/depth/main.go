@@ -0,0 +1,309 @@
+// Command depth generates the depth-100 and depth-1000 testdata of package
+// orderbook. No live capture of deeper book subscriptions is available, so the
+// messages are synthesized in the Kraken v1 wire format. In order not to verify
+// the order book against checksums computed by the code under test, depth does
+// not import package orderbook. It maintains its own order book based on the
+// wire strings and computes every checksum as documented by Kraken.
+//
+//	go run ./pkg/orderbook/testdata/depth
+//
+// Every line of the generated files is a single book payload. The first line
+// is a snapshot of the given depth, followed by updates that change volumes,
+// insert price levels pushing the worst level out of scope, and delete price
+// levels together with republishing the level moving into scope, like Kraken
+// does. Both sides always keep exactly the subscribed depth.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mid is the initial mid price in units of 0.00001.
+	mid = 167700000
+	// upd is the number of updates following the snapshot.
+	upd = 248
+)
+
+func main() {
+	for _, dep := range []int{100, 1000} {
+		err := generate(dep, filepath.Join("pkg", "orderbook", "testdata", fmt.Sprintf("depth-%d.jsonl", dep)))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// level is a price level in units of 0.00001 and 0.00000001 respectively.
+type level struct {
+	pri int64
+	vol int64
+}
+
+// side keeps the price levels of one side, sorted from best to worst.
+type side struct {
+	asc bool
+	lev []level
+}
+
+func (s *side) sort() {
+	sort.Slice(s.lev, func(i, j int) bool {
+		if s.asc {
+			return s.lev[i].pri < s.lev[j].pri
+		}
+		return s.lev[i].pri > s.lev[j].pri
+	})
+}
+
+func (s *side) has(pri int64) bool {
+	for _, x := range s.lev {
+		if x.pri == pri {
+			return true
+		}
+	}
+
+	return false
+}
+
+// worse returns a price one to ten ticks beyond the worst price level.
+func (s *side) worse(rnd *rand.Rand) int64 {
+	pri := s.lev[len(s.lev)-1].pri
+	if s.asc {
+		return pri + int64(1+rnd.Intn(10))*10
+	}
+	return pri - int64(1+rnd.Intn(10))*10
+}
+
+func generate(dep int, pat string) error {
+	var rnd *rand.Rand
+	{
+		rnd = rand.New(rand.NewSource(int64(dep)))
+	}
+
+	var ask *side
+	var bid *side
+	{
+		ask = &side{asc: true}
+		bid = &side{asc: false}
+	}
+
+	{
+		ask.lev = append(ask.lev, level{pri: mid + 1000, vol: volume(rnd)})
+		bid.lev = append(bid.lev, level{pri: mid - 1000, vol: volume(rnd)})
+	}
+
+	for len(ask.lev) < dep {
+		ask.lev = append(ask.lev, level{pri: ask.worse(rnd), vol: volume(rnd)})
+		bid.lev = append(bid.lev, level{pri: bid.worse(rnd), vol: volume(rnd)})
+	}
+
+	var lin []map[string]interface{}
+	{
+		lin = append(lin, map[string]interface{}{
+			"as": wire(ask.lev, timestamp(0), false),
+			"bs": wire(bid.lev, timestamp(0), false),
+		})
+	}
+
+	for i := 1; i <= upd; i++ {
+		var tim string
+		{
+			tim = timestamp(i)
+		}
+
+		var pay map[string]interface{}
+		{
+			pay = map[string]interface{}{}
+		}
+
+		for _, x := range []struct {
+			key string
+			sid *side
+		}{{key: "a", sid: ask}, {key: "b", sid: bid}} {
+			if rnd.Intn(3) == 0 {
+				continue
+			}
+
+			var raw [][]string
+			for j := 0; j < 1+rnd.Intn(2); j++ {
+				raw = append(raw, change(rnd, x.sid, tim)...)
+			}
+
+			pay[x.key] = raw
+		}
+
+		if len(pay) == 0 {
+			pay["a"] = change(rnd, ask, tim)
+		}
+
+		{
+			pay["c"] = checksum(ask, bid)
+		}
+
+		lin = append(lin, pay)
+	}
+
+	var out []string
+	for _, x := range lin {
+		byt, err := marshal(x)
+		if err != nil {
+			return err
+		}
+
+		out = append(out, string(byt))
+	}
+
+	return os.WriteFile(pat, []byte(strings.Join(out, "\n")+"\n"), 0o644)
+}
+
+// change applies a random change to the given side and returns its price
+// levels in wire format. New price levels are placed behind the best price
+// level, so that the order book never gets crossed.
+func change(rnd *rand.Rand, sid *side, tim string) [][]string {
+	switch rnd.Intn(3) {
+	case 0:
+		i := index(rnd, sid)
+		sid.lev[i].vol = volume(rnd)
+		return wire(sid.lev[i:i+1], tim, false)
+	case 1:
+		// A new price level within the book pushes the worst price level out
+		// of scope. Kraken does not send a delete for it.
+		var pri int64
+		for {
+			pri = sid.lev[0].pri + rnd.Int63n(abs(sid.lev[index(rnd, sid)].pri-sid.lev[0].pri)+1)*sign(sid)
+			if !sid.has(pri) {
+				break
+			}
+		}
+
+		lev := level{pri: pri, vol: volume(rnd)}
+		sid.lev = append(sid.lev, lev)
+		sid.sort()
+		sid.lev = sid.lev[:len(sid.lev)-1]
+		return wire([]level{lev}, tim, false)
+	default:
+		// A deleted price level gets followed by the republish of the price
+		// level moving into scope.
+		i := index(rnd, sid)
+		del := level{pri: sid.lev[i].pri}
+		rep := level{pri: sid.worse(rnd), vol: volume(rnd)}
+		sid.lev = append(sid.lev[:i], sid.lev[i+1:]...)
+		sid.lev = append(sid.lev, rep)
+		return append(wire([]level{del}, tim, false), wire([]level{rep}, tim, true)...)
+	}
+}
+
+// index returns the index of a random price level of the given side. Half of
+// all indices fall into the top twelve price levels, so that most changes
+// affect the checksum.
+func index(rnd *rand.Rand, sid *side) int {
+	if rnd.Intn(2) == 0 {
+		return rnd.Intn(12)
+	}
+
+	return rnd.Intn(len(sid.lev))
+}
+
+// checksum computes the CRC32 checksum of the top ten ask and bid price levels
+// as documented at https://docs.kraken.com/websockets/#book-checksum.
+func checksum(ask *side, bid *side) string {
+	var con strings.Builder
+
+	for _, x := range [][]level{top(ask.lev), top(bid.lev)} {
+		for _, y := range x {
+			con.WriteString(digits(price(y.pri)))
+			con.WriteString(digits(volumeString(y.vol)))
+		}
+	}
+
+	return strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(con.String()))), 10)
+}
+
+// digits removes the decimal point and all leading zeros of the given wire
+// string.
+func digits(str string) string {
+	return strings.TrimLeft(strings.Replace(str, ".", "", 1), "0")
+}
+
+func sign(sid *side) int64 {
+	if sid.asc {
+		return 1
+	}
+	return -1
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func top(lev []level) []level {
+	if len(lev) > 10 {
+		return lev[:10]
+	}
+	return lev
+}
+
+func volume(rnd *rand.Rand) int64 {
+	return 1 + rnd.Int63n(50_00000000)
+}
+
+func price(pri int64) string {
+	return fmt.Sprintf("%d.%05d", pri/100000, pri%100000)
+}
+
+func volumeString(vol int64) string {
+	return fmt.Sprintf("%d.%08d", vol/100000000, vol%100000000)
+}
+
+func timestamp(i int) string {
+	return fmt.Sprintf("%d.%06d", 1679000000+i, (i*7919)%1000000)
+}
+
+func wire(lev []level, tim string, rep bool) [][]string {
+	var raw [][]string
+
+	for _, x := range lev {
+		str := []string{price(x.pri), volumeString(x.vol), tim}
+		if rep {
+			str = append(str, "r")
+		}
+
+		raw = append(raw, str)
+	}
+
+	return raw
+}
+
+// marshal encodes the given payload with its keys in wire order.
+func marshal(pay map[string]interface{}) ([]byte, error) {
+	var key []string
+	for _, x := range []string{"as", "bs", "a", "b", "c"} {
+		if _, ok := pay[x]; ok {
+			key = append(key, x)
+		}
+	}
+
+	var out []string
+	for _, x := range key {
+		byt, err := json.Marshal(pay[x])
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, fmt.Sprintf("%q:%s", x, byt))
+	}
+
+	return []byte("{" + strings.Join(out, ",") + "}"), nil
+}
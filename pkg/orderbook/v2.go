@@ -0,0 +1,113 @@
+package orderbook
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RawV2 is a book channel message of the Kraken websocket API v2. Other than
+// the v1 API, prices and quantities are encoded as JSON numbers, so that
+// trailing zeros get lost on the wire. The checksum calculation requires the
+// values to be formatted using the precision of the instrument again.
+//
+//	https://docs.kraken.com/api/docs/websocket-v2/book
+//	https://docs.kraken.com/api/docs/guides/spot-ws-book-v2
+type RawV2 struct {
+	Channel string   `json:"channel"`
+	Type    string   `json:"type"`
+	Data    []BookV2 `json:"data"`
+}
+
+// BookV2 is the book data of a single symbol within a RawV2 message.
+type BookV2 struct {
+	Symbol    string    `json:"symbol"`
+	Asks      []LevelV2 `json:"asks"`
+	Bids      []LevelV2 `json:"bids"`
+	Checksum  uint32    `json:"checksum"`
+	Timestamp string    `json:"timestamp"`
+}
+
+// LevelV2 is a single price level within a BookV2.
+type LevelV2 struct {
+	Price Decimal `json:"price"`
+	Qty   Decimal `json:"qty"`
+}
+
+// Response converts the v2 book message into the same Response the v1 Raw
+// message produces, so that the v2 API maintains the same Orderbook. The given
+// price and quantity precision are the price_precision and qty_precision of
+// the instrument, as provided e.g. by the v2 instrument channel. Prices and
+// volumes of the returned Response are formatted using these precisions, so
+// that the checksum calculation of Orderbook.Middleware applies to v1 and v2
+// alike. Response returns an error if the message is not a book snapshot or
+// update for exactly one symbol, or if any value exceeds the given precision.
+func (r RawV2) Response(pri int32, qty int32) (Response, error) {
+	if r.Channel != "book" {
+		return Response{}, fmt.Errorf("channel (%s) must be book", r.Channel)
+	}
+
+	if r.Type != "snapshot" && r.Type != "update" {
+		return Response{}, fmt.Errorf("type (%s) must be snapshot or update", r.Type)
+	}
+
+	if len(r.Data) != 1 {
+		return Response{}, fmt.Errorf("book message must contain data for exactly 1 symbol, got %d", len(r.Data))
+	}
+
+	var ask []Object
+	var bid []Object
+
+	for i, x := range r.Data[0].Asks {
+		obj, err := objectV2(x, pri, qty)
+		if err != nil {
+			return Response{}, fmt.Errorf("price level %d of asks: %w", i, err)
+		}
+
+		{
+			ask = append(ask, obj)
+		}
+	}
+
+	for i, x := range r.Data[0].Bids {
+		obj, err := objectV2(x, pri, qty)
+		if err != nil {
+			return Response{}, fmt.Errorf("price level %d of bids: %w", i, err)
+		}
+
+		{
+			bid = append(bid, obj)
+		}
+	}
+
+	var res Response
+	{
+		res = Response{
+			Asks:       ask,
+			Bids:       bid,
+			CheckSum:   strconv.FormatUint(uint64(r.Data[0].Checksum), 10),
+			IsSnapshot: r.Type == "snapshot",
+		}
+	}
+
+	return res, nil
+}
+
+func objectV2(lev LevelV2, pri int32, qty int32) (Object, error) {
+	if lev.Price.Scale() > pri {
+		return Object{}, fmt.Errorf("price (%s) must not have more than %d decimals", lev.Price, pri)
+	}
+
+	if lev.Qty.Scale() > qty {
+		return Object{}, fmt.Errorf("quantity (%s) must not have more than %d decimals", lev.Qty, qty)
+	}
+
+	var obj Object
+	{
+		obj = Object{
+			Price:  lev.Price.Rescale(pri),
+			Volume: lev.Qty.Rescale(qty),
+		}
+	}
+
+	return obj, nil
+}
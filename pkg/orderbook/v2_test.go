@@ -0,0 +1,208 @@
+package orderbook
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+// Test_RawV2_Response aims to cover order book management using messages of
+// the v2 API. No live capture of the v2 API is available. The testdata
+// contains the first messages of the golden success capture instead, encoded
+// in the v2 format with numeric prices and quantities, so that trailing zeros
+// get lost. The v2 snapshot is the order book state after the first v1 update,
+// and every v2 message carries the checksum Kraken sent with the respective v1
+// update. None of the checksums got computed by this package. Given the price
+// precision of the v1 capture, both order books must end up in the same state.
+func Test_RawV2_Response(t *testing.T) {
+	var ov1 *Orderbook
+	var ov2 *Orderbook
+	{
+		ov1 = New(Config{Dep: 10})
+		ov2 = New(Config{Dep: 10})
+	}
+
+	var raw []RawV2
+	{
+		raw = testdatav2()
+	}
+
+	for i, x := range raw {
+		rsp, err := x.Response(5, 8)
+		if err != nil {
+			t.Fatalf("message %d: %s", i, err)
+		}
+
+		err = ov2.Middleware(rsp)
+		if err != nil {
+			t.Fatalf("message %d: %s", i, err)
+		}
+	}
+
+	for _, x := range testdatas()[:len(raw)+1] {
+		err := ov1.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if ov1.ask.len() != ov2.ask.len() || ov1.bid.len() != ov2.bid.len() {
+		t.Fatalf("expected %d ask and %d bid levels, got %d and %d", ov1.ask.len(), ov1.bid.len(), ov2.ask.len(), ov2.bid.len())
+	}
+
+	for i := range ov1.ask.lev {
+		if ov1.ask.lev[i].Price.Cmp(ov2.ask.lev[i].Price) != 0 || ov1.ask.lev[i].Volume.Cmp(ov2.ask.lev[i].Volume) != 0 {
+			t.Fatalf("ask %d: expected %s@%s, got %s@%s", i, ov1.ask.lev[i].Volume, ov1.ask.lev[i].Price, ov2.ask.lev[i].Volume, ov2.ask.lev[i].Price)
+		}
+	}
+
+	for i := range ov1.bid.lev {
+		if ov1.bid.lev[i].Price.Cmp(ov2.bid.lev[i].Price) != 0 || ov1.bid.lev[i].Volume.Cmp(ov2.bid.lev[i].Volume) != 0 {
+			t.Fatalf("bid %d: expected %s@%s, got %s@%s", i, ov1.bid.lev[i].Volume, ov1.bid.lev[i].Price, ov2.bid.lev[i].Volume, ov2.bid.lev[i].Price)
+		}
+	}
+}
+
+// Test_RawV2_Response_Checksum aims to cover that v2 snapshots are verified
+// against their checksum, other than v1 snapshots which do not carry one.
+func Test_RawV2_Response_Checksum(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	var raw RawV2
+	{
+		raw = testdatav2()[0]
+		raw.Data[0].Checksum++
+	}
+
+	rsp, err := raw.Response(5, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var che *ChecksumError
+	if !errors.As(ord.Middleware(rsp), &che) {
+		t.Fatal("expected snapshot with wrong checksum to cause a checksum error")
+	}
+}
+
+// Test_RawV2_Response_Precision aims to cover the checksum formatting of the v2
+// API with the real v2 price precision of ETH/USD, which is 2, other than the 5
+// decimals of the v1 API. The expected checksum of the snapshot got computed
+// independently of this package, by formatting every price with 2 and every
+// quantity with 8 decimals as described in the v2 book checksum guide. The same
+// snapshot formatted with the v1 decimals must not verify against it, and vice
+// versa.
+func Test_RawV2_Response_Precision(t *testing.T) {
+	testCases := []struct {
+		pri int32
+		sum uint32
+		val bool
+	}{
+		// Case 000 uses the v2 price precision of ETH/USD.
+		{pri: 2, sum: 4210878596, val: true},
+		// Case 001 uses the v1 price decimals of ETH/USD.
+		{pri: 5, sum: 3809965286, val: true},
+		// Case 002 verifies the v2 checksum using the v1 price decimals.
+		{pri: 5, sum: 4210878596, val: false},
+		// Case 003 verifies the v1 checksum using the v2 price precision.
+		{pri: 2, sum: 3809965286, val: false},
+	}
+
+	for i, tc := range testCases {
+		var raw RawV2
+		{
+			raw = testdatav2()[0]
+			raw.Data[0].Checksum = tc.sum
+		}
+
+		rsp, err := raw.Response(tc.pri, 8)
+		if err != nil {
+			t.Fatalf("test case %d: %s", i, err)
+		}
+
+		err = New(Config{Dep: 10}).Middleware(rsp)
+		if (err == nil) != tc.val {
+			t.Fatalf("test case %d: expected checksum to verify %t, got %v", i, tc.val, err)
+		}
+	}
+}
+
+func Test_RawV2_Response_Malformed(t *testing.T) {
+	testCases := []string{
+		// Case 000 is not a book message.
+		`{"channel":"ticker","type":"update","data":[{"symbol":"ETH/USD"}]}`,
+		// Case 001 has an unknown type.
+		`{"channel":"book","type":"delta","data":[{"symbol":"ETH/USD"}]}`,
+		// Case 002 contains no data.
+		`{"channel":"book","type":"update","data":[]}`,
+		// Case 003 contains a price exceeding the price precision.
+		`{"channel":"book","type":"update","data":[{"symbol":"ETH/USD","asks":[{"price":1272.745,"qty":1}],"bids":[],"checksum":1}]}`,
+		// Case 004 contains a quantity exceeding the quantity precision.
+		`{"channel":"book","type":"update","data":[{"symbol":"ETH/USD","asks":[],"bids":[{"price":1272.74,"qty":1.000000001}],"checksum":1}]}`,
+	}
+
+	for i, tc := range testCases {
+		var raw RawV2
+		{
+			err := json.Unmarshal([]byte(tc), &raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err := raw.Response(2, 8)
+		if err == nil {
+			t.Fatalf("case %03d: expected malformed message to cause an error", i)
+		}
+	}
+}
+
+// testdatav2 returns the v2 book messages from the testdata directory.
+func testdatav2() []RawV2 {
+	var err error
+
+	var fil *os.File
+	{
+		fil, err = os.Open("testdata/v2-book-10.jsonl")
+		if err != nil {
+			panic(err)
+		}
+		defer fil.Close()
+	}
+
+	var res []RawV2
+
+	var sca *bufio.Scanner
+	{
+		sca = bufio.NewScanner(fil)
+		sca.Buffer(nil, 1024*1024)
+	}
+
+	for sca.Scan() {
+		var raw RawV2
+		{
+			err = json.Unmarshal(sca.Bytes(), &raw)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		{
+			res = append(res, raw)
+		}
+	}
+
+	{
+		err = sca.Err()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return res
+}
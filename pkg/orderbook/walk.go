@@ -0,0 +1,65 @@
+package orderbook
+
+// DepthLevel is a single price level as visited by Orderbook.WalkAsks and
+// Orderbook.WalkBids, together with the cumulative volume and notional of all
+// price levels visited so far, including the current one. The notional of a
+// price level is its price multiplied by its volume.
+type DepthLevel struct {
+	Price              Decimal
+	Volume             Decimal
+	CumulativeVolume   Decimal
+	CumulativeNotional Decimal
+}
+
+// WalkAsks calls fnc for every ask price level, sorted by price from low to
+// high, until fnc returns false. The price levels are copied under lock before
+// fnc is called for the first time. So fnc always observes a consistent order
+// book state and is free to call any other method of Orderbook.
+func (o *Orderbook) WalkAsks(fnc func(DepthLevel) bool) {
+	var lev []Level
+	{
+		o.mut.Lock()
+		lev = append(lev, o.ask.lev...)
+		o.mut.Unlock()
+	}
+
+	walk(lev, fnc)
+}
+
+// WalkBids calls fnc for every bid price level, sorted by price from high to
+// low, until fnc returns false. The price levels are copied under lock before
+// fnc is called for the first time. So fnc always observes a consistent order
+// book state and is free to call any other method of Orderbook.
+func (o *Orderbook) WalkBids(fnc func(DepthLevel) bool) {
+	var lev []Level
+	{
+		o.mut.Lock()
+		lev = append(lev, o.bid.lev...)
+		o.mut.Unlock()
+	}
+
+	walk(lev, fnc)
+}
+
+func walk(lev []Level, fnc func(DepthLevel) bool) {
+	var vol Decimal
+	var not Decimal
+
+	for _, x := range lev {
+		{
+			vol = vol.Add(x.Volume)
+			not = not.Add(x.Price.Mul(x.Volume))
+		}
+
+		dep := DepthLevel{
+			Price:              x.Price,
+			Volume:             x.Volume,
+			CumulativeVolume:   vol,
+			CumulativeNotional: not,
+		}
+
+		if !fnc(dep) {
+			return
+		}
+	}
+}
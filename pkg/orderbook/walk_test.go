@@ -0,0 +1,66 @@
+package orderbook
+
+import (
+	"testing"
+)
+
+// Test_Orderbook_Walk aims to cover the depth iteration API using the final
+// order book state of the success testdata. Cumulative volume and notional
+// must add up level by level, and callbacks must be able to query the order
+// book while walking it.
+func Test_Orderbook_Walk(t *testing.T) {
+	var ord *Orderbook
+	{
+		ord = New(Config{Dep: 10})
+	}
+
+	for _, x := range testdatas() {
+		err := ord.Middleware(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, wlk := range []func(func(DepthLevel) bool){ord.WalkAsks, ord.WalkBids} {
+		var lev []DepthLevel
+		wlk(func(x DepthLevel) bool {
+			_, _ = ord.MidPrice()
+			lev = append(lev, x)
+			return true
+		})
+
+		if len(lev) != 10 {
+			t.Fatalf("expected 10 price levels, got %d", len(lev))
+		}
+
+		var vol Decimal
+		var not Decimal
+		for i, x := range lev {
+			vol = vol.Add(x.Volume)
+			not = not.Add(x.Price.Mul(x.Volume))
+
+			if x.CumulativeVolume.Cmp(vol) != 0 {
+				t.Fatalf("level %d: expected cumulative volume %s, got %s", i, vol, x.CumulativeVolume)
+			}
+			if x.CumulativeNotional.Cmp(not) != 0 {
+				t.Fatalf("level %d: expected cumulative notional %s, got %s", i, not, x.CumulativeNotional)
+			}
+		}
+	}
+
+	var lev []DepthLevel
+	ord.WalkAsks(func(x DepthLevel) bool {
+		lev = append(lev, x)
+		return len(lev) < 2
+	})
+
+	if len(lev) != 2 {
+		t.Fatalf("expected walk to stop after 2 price levels, got %d", len(lev))
+	}
+	if lev[0].Price.String() != "1274.47000" || lev[1].Price.String() != "1274.87000" {
+		t.Fatalf("expected asks from low to high, got %s and %s", lev[0].Price, lev[1].Price)
+	}
+	if lev[1].CumulativeVolume.String() != "37.70594355" {
+		t.Fatalf("expected cumulative volume 37.70594355, got %s", lev[1].CumulativeVolume)
+	}
+}
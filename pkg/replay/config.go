@@ -0,0 +1,33 @@
+package replay
+
+import "github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+
+type Config struct {
+	// Spe is the speed multiplier records get replayed with, relative to the
+	// receive times of the capture. One replays in real time, two twice as
+	// fast. Zero replays as fast as possible.
+	Spe float64
+	// Upd is called for every book message that got applied to an order book
+	// and passed checksum verification. Upd may be nil.
+	Upd func(Update)
+}
+
+func (c Config) Verify() {
+	if c.Spe < 0 {
+		panic("Config.Spe must not be negative")
+	}
+}
+
+// Update is delivered via Config.Upd for every verified book message.
+type Update struct {
+	// Book is the order book the update got applied to.
+	Book *orderbook.Orderbook
+	// File is the capture file the update got read from.
+	File string
+	// Offset is the index of the record within File, counting from zero.
+	Offset int
+	// Pair is the asset pair of the order book, e.g. "ETH/USD".
+	Pair string
+	// Response is the book message that got applied.
+	Response orderbook.Response
+}
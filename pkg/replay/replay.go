@@ -0,0 +1,244 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phoebetronic/orderbook-kraken/pkg/capture"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Failure describes a record that could not be replayed.
+type Failure struct {
+	// Err describes the failure. Checksum mismatches can be detected via
+	// errors.As using *orderbook.ChecksumError.
+	Err error
+	// File is the capture file the record got read from.
+	File string
+	// Offset is the index of the record within File, counting from zero.
+	Offset int
+	// Pair is the asset pair of the record, if known.
+	Pair string
+}
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Offset, f.Err)
+}
+
+func (f Failure) Unwrap() error {
+	return f.Err
+}
+
+// Report summarizes a replay.
+type Report struct {
+	// Dropped is the number of book messages that got dropped, because the
+	// order book of their pair was invalid, e.g. after a checksum mismatch,
+	// and the message was not a snapshot.
+	Dropped int
+	// Failures are all records that could not be replayed, in order.
+	Failures []Failure
+	// Frames is the number of book messages that got applied and verified.
+	Frames int
+	// Records is the number of records read, including events.
+	Records int
+}
+
+// Replayer feeds captured raw messages through the same decoding path the
+// client uses, that is, orderbook.Decode, orderbook.Frame.Response and
+// orderbook.Orderbook.Middleware, maintaining one order book per pair. Replays
+// are deterministic. Given the same capture files, the same updates and
+// failures get reported in the same order.
+type Replayer struct {
+	bok map[string]*orderbook.Orderbook
+	con Config
+}
+
+func New(con Config) *Replayer {
+	{
+		con.Verify()
+	}
+
+	return &Replayer{
+		bok: map[string]*orderbook.Orderbook{},
+		con: con,
+	}
+}
+
+// Book returns the order book of the given pair, or nil if no book message of
+// the pair got replayed.
+func (r *Replayer) Book(pai string) *orderbook.Orderbook {
+	return r.bok[pai]
+}
+
+// Run replays all records of the given capture files in order. Like the
+// client, Run resets the order book of a pair once any of its book messages
+// cannot be applied, be it due to malformed price levels, a crossed snapshot or
+// a checksum mismatch, and drops its book messages until the next snapshot,
+// which the client would have resubscribed for. Also like the client, messages
+// that cannot be decoded at all only get reported, since their pair is unknown.
+// Failing records get reported, while Run only returns an error if a capture
+// file cannot be opened or the given context got cancelled. Capture files that
+// were not closed properly get replayed up to their last complete record,
+// followed by a failure.
+func (r *Replayer) Run(ctx context.Context, pat ...string) (Report, error) {
+	var rep Report
+
+	var fir time.Time
+	var sta time.Time
+
+	for _, x := range pat {
+		var rea *capture.Reader
+		{
+			var err error
+
+			rea, err = capture.Open(x)
+			if err != nil {
+				return rep, err
+			}
+		}
+
+		for off := 0; ; off++ {
+			if ctx.Err() != nil {
+				rea.Close()
+				return rep, ctx.Err()
+			}
+
+			rec, err := rea.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				rep.Failures = append(rep.Failures, Failure{Err: err, File: x, Offset: off})
+				break
+			}
+
+			{
+				rep.Records++
+			}
+
+			if fir.IsZero() {
+				fir = rec.Time
+				sta = time.Now()
+			}
+
+			if r.con.Spe != 0 {
+				err = wait(ctx, sta.Add(time.Duration(float64(rec.Time.Sub(fir))/r.con.Spe)))
+				if err != nil {
+					rea.Close()
+					return rep, err
+				}
+			}
+
+			{
+				r.record(x, off, rec, &rep)
+			}
+		}
+
+		{
+			rea.Close()
+		}
+	}
+
+	return rep, nil
+}
+
+// book returns the order book of the given pair, which gets created with the
+// depth of the given channel name on first use.
+func (r *Replayer) book(pai string, nam string) (*orderbook.Orderbook, error) {
+	if r.bok[pai] != nil {
+		return r.bok[pai], nil
+	}
+
+	var dep int
+	{
+		dep, _ = strconv.Atoi(strings.TrimPrefix(nam, "book-"))
+	}
+
+	for _, x := range orderbook.Depths {
+		if dep == x {
+			r.bok[pai] = orderbook.New(orderbook.Config{Dep: dep})
+			return r.bok[pai], nil
+		}
+	}
+
+	return nil, fmt.Errorf("channel name %q must specify one of the depths %v", nam, orderbook.Depths)
+}
+
+// record replays a single record and adds its outcome to the given report.
+func (r *Replayer) record(fil string, off int, rec capture.Record, rep *Report) {
+	var err error
+
+	var msg orderbook.Message
+	{
+		msg, err = orderbook.Decode(rec.Message)
+		if err != nil {
+			rep.Failures = append(rep.Failures, Failure{Err: err, File: fil, Offset: off})
+			return
+		}
+	}
+
+	if msg.Frame == nil || !msg.Frame.Book() {
+		return
+	}
+
+	var obk *orderbook.Orderbook
+	{
+		obk, err = r.book(msg.Frame.Pair, msg.Frame.ChannelName)
+		if err != nil {
+			rep.Failures = append(rep.Failures, Failure{Err: err, File: fil, Offset: off, Pair: msg.Frame.Pair})
+			return
+		}
+	}
+
+	var rsp orderbook.Response
+	{
+		rsp, err = msg.Frame.Response()
+		if err != nil {
+			obk.Reset()
+			rep.Failures = append(rep.Failures, Failure{Err: err, File: fil, Offset: off, Pair: msg.Frame.Pair})
+			return
+		}
+	}
+
+	if !obk.Valid() && !rsp.IsSnapshot {
+		rep.Dropped++
+		return
+	}
+
+	{
+		err = obk.Middleware(rsp)
+		if err != nil {
+			obk.Reset()
+			rep.Failures = append(rep.Failures, Failure{Err: err, File: fil, Offset: off, Pair: msg.Frame.Pair})
+			return
+		}
+	}
+
+	{
+		rep.Frames++
+	}
+
+	if r.con.Upd != nil {
+		r.con.Upd(Update{Book: obk, File: fil, Offset: off, Pair: msg.Frame.Pair, Response: rsp})
+	}
+}
+
+// wait blocks until the given time or until the given context got cancelled.
+func wait(ctx context.Context, tim time.Time) error {
+	var tic *time.Timer
+	{
+		tic = time.NewTimer(time.Until(tim))
+		defer tic.Stop()
+	}
+
+	select {
+	case <-tic.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,204 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phoebetronic/orderbook-kraken/pkg/capture"
+	"github.com/phoebetronic/orderbook-kraken/pkg/orderbook"
+)
+
+// Test_Replayer_Run ensures that failures get reported by record offset, that
+// the order book recovers with the next snapshot after a checksum mismatch, and
+// that a malformed book message resets the order book like in the client.
+// Records are split across two capture files.
+func Test_Replayer_Run(t *testing.T) {
+	var pat []string
+	{
+		pat = record(t, 250*time.Millisecond)
+	}
+
+	if len(pat) != 2 {
+		t.Fatalf("expected 2 capture files, got %d", len(pat))
+	}
+
+	var bid string
+	var off []string
+	var rpl *Replayer
+	{
+		rpl = New(Config{Upd: func(upd Update) {
+			lev, _ := upd.Book.BestBid()
+			bid = lev.Price.String()
+			off = append(off, fmt.Sprintf("%d", upd.Offset))
+		}})
+	}
+
+	var rep Report
+	{
+		var err error
+
+		rep, err = rpl.Run(context.Background(), pat...)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if rep.Records != 9 || rep.Frames != 4 || rep.Dropped != 1 {
+		t.Fatalf("expected 9 records, 4 frames and 1 dropped, got %#v", rep)
+	}
+
+	if len(rep.Failures) != 2 {
+		t.Fatalf("expected 2 failures, got %d", len(rep.Failures))
+	}
+
+	var che *orderbook.ChecksumError
+	if rep.Failures[0].File != pat[0] || rep.Failures[0].Offset != 3 || rep.Failures[0].Pair != "ETH/USD" || !errors.As(rep.Failures[0], &che) {
+		t.Fatalf("expected checksum failure at offset 3 of the first file, got %s", rep.Failures[0])
+	}
+
+	if rep.Failures[1].File != pat[1] || rep.Failures[1].Offset != 3 || errors.As(rep.Failures[1], &che) {
+		t.Fatalf("expected malformed message at offset 3 of the second file, got %s", rep.Failures[1])
+	}
+
+	// Offsets are counted per capture file, so that the offsets of the second
+	// file restart from zero.
+
+	if strings.Join(off, ",") != "1,2,0,1" {
+		t.Fatalf("expected updates at offsets 1, 2, 0 and 1, got %v", off)
+	}
+
+	if bid != "1271.81000" {
+		t.Fatalf("expected best bid 1271.81000, got %s", bid)
+	}
+
+	if rpl.Book("ETH/USD").Valid() {
+		t.Fatal("expected order book to be reset after malformed message")
+	}
+}
+
+// Test_Replayer_Run_Speed ensures that records get paced by their receive
+// times, scaled by the speed multiplier.
+func Test_Replayer_Run_Speed(t *testing.T) {
+	var pat []string
+	{
+		pat = record(t, 0)
+	}
+
+	testCases := []struct {
+		spe float64
+		low time.Duration
+		upp time.Duration
+	}{
+		{spe: 0, low: 0, upp: 50 * time.Millisecond},
+		{spe: 4, low: 100 * time.Millisecond, upp: 300 * time.Millisecond},
+	}
+
+	for i, tc := range testCases {
+		var sta time.Time
+		{
+			sta = time.Now()
+		}
+
+		{
+			_, err := New(Config{Spe: tc.spe}).Run(context.Background(), pat...)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if time.Since(sta) < tc.low || time.Since(sta) > tc.upp {
+			t.Fatalf("test case %d: expected replay to take between %s and %s, got %s", i, tc.low, tc.upp, time.Since(sta))
+		}
+	}
+
+	{
+		ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer can()
+
+		_, err := New(Config{Spe: 0.01}).Run(ctx, pat...)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected replay to be cancelled, got %v", err)
+		}
+	}
+}
+
+// record writes the records of testdata into capture files, rotated after the
+// given time span, and returns their paths.
+func record(t *testing.T, dur time.Duration) []string {
+	var con capture.Config
+	{
+		con = capture.Config{Dir: t.TempDir(), Dur: dur}
+	}
+
+	var rec *capture.Recorder
+	{
+		rec = capture.New(con)
+	}
+
+	for _, x := range testdata() {
+		err := rec.Record(x.Time, x.Message)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	{
+		err := rec.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pat, err := capture.Files(con.Dir, con.Pre)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pat
+}
+
+// testdata returns nine records received 50 milliseconds apart. The snapshot
+// and the first update get recorded twice, once followed by an update with a
+// corrupted checksum and the original update, which gets dropped, and once
+// followed by a heartbeat and a malformed message.
+func testdata() []capture.Record {
+	var snp string
+	var fir string
+	var sec string
+	{
+		snp = `[560,{"as":[["1272.70000","1.00000000","1669902400.950657"],["1272.71000","2.39742752","1669902400.924689"],["1272.72000","3.72191238","1669902400.751811"],["1272.74000","5.50000000","1669902400.561752"],["1272.77000","4.05813845","1669902400.783276"],["1272.78000","25.58235843","1669902400.589057"],["1272.79000","5.00000000","1669902400.593059"],["1272.80000","2.99895264","1669902400.133970"],["1272.85000","2.24585184","1669902400.018159"],["1272.86000","1.00000000","1669902399.934519"]],"bs":[["1271.80000","4.03174746","1669902400.824471"],["1271.79000","2.26656312","1669902391.019095"],["1271.60000","0.65439574","1669902400.732617"],["1271.59000","26.08235843","1669902400.588707"],["1271.58000","5.50000000","1669902399.728626"],["1271.57000","3.87724786","1669902399.586119"],["1271.56000","3.89057615","1669902396.164743"],["1271.54000","4.74487479","1669902395.334591"],["1271.49000","58.98572653","1669902394.921835"],["1271.40000","1.93804942","1669902389.029716"]]},"book-10","ETH/USD"]`
+		fir = `[560,{"a":[["1272.70000","0.50000000","1669902401.100000"]]},{"b":[["1271.81000","3.25000000","1669902401.100001"]],"c":"3509629784"},"book-10","ETH/USD"]`
+		sec = `[560,{"a":[["1272.71000","0.00000000","1669902401.200000"],["1273.36000","1.00000000","1669902401.200000","r"]]},{"b":[["1271.79000","0.10000000","1669902401.200001"]],"c":"1085567585"},"book-10","ETH/USD"]`
+	}
+
+	var msg []string
+	{
+		msg = []string{
+			`{"connectionID":1,"event":"systemStatus","status":"online","version":"1.9.0"}`,
+			snp,
+			fir,
+			strings.Replace(sec, `"c":"1085567585"`, `"c":"1"`, 1),
+			sec,
+			snp,
+			fir,
+			`{"event":"heartbeat"}`,
+			`[560,{"a":[["1272.70000"]]},"book-10","ETH/USD"]`,
+		}
+	}
+
+	var tim time.Time
+	{
+		tim = time.Date(2022, 12, 1, 13, 40, 0, 0, time.UTC)
+	}
+
+	var all []capture.Record
+	for i, x := range msg {
+		all = append(all, capture.Record{Message: []byte(x), Time: tim.Add(time.Duration(i) * 50 * time.Millisecond)})
+	}
+
+	return all
+}